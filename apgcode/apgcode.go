@@ -0,0 +1,280 @@
+package apgcode
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/irainia/gameoflife-go/transform"
+)
+
+const (
+	StillLifePrefix  = "xs"
+	OscillatorPrefix = "xp"
+	SpaceshipPrefix  = "xq"
+
+	prefixSeparator = "_"
+)
+
+const (
+	NilCellStateError     = "cell state passed is nil"
+	ExtinctCellStateError = "cell state passed is extinct"
+	InvalidMaxPeriodError = "max period is less than one (should be at least 1)"
+	NotPeriodicError      = "pattern is not periodic within max period"
+	InvalidCodeError      = "apgcode is invalid (use: xs/xp/xq[number]_[wechsler], e.g. xs4_33)"
+)
+
+var (
+	ErrNilCellState     = errors.New(NilCellStateError)
+	ErrExtinctCellState = errors.New(ExtinctCellStateError)
+	ErrInvalidMaxPeriod = errors.New(InvalidMaxPeriodError)
+	ErrNotPeriodic      = errors.New(NotPeriodicError)
+	ErrInvalidCode      = errors.New(InvalidCodeError)
+)
+
+const (
+	stripHeight    = 5
+	stripSeparator = 'z'
+	twoZeros       = 'w'
+	threeZeros     = 'x'
+	manyZeros      = 'y'
+	minManyZeros   = 4
+	maxManyZeros   = minManyZeros + len(zeroRunDigits) - 1
+
+	columnDigits  = "0123456789abcdefghijklmnopqrstuv"
+	zeroRunDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// Classify evolves cellState for at most maxPeriod generations and returns
+// its period with the row and column shift over one period.
+func Classify(cellState *cell.CellState, maxPeriod int) (int, int, int, error) {
+	if cellState == nil {
+		return 0, 0, 0, ErrNilCellState
+	}
+	if maxPeriod < 1 {
+		return 0, 0, 0, ErrInvalidMaxPeriod
+	}
+	if len(cellState.GetGeneration()) == 0 {
+		return 0, 0, 0, ErrExtinctCellState
+	}
+
+	rowOffset, colOffset := cellState.GetOffset()
+	shape := cellState.String()
+	currentState := cellState
+	for period := 1; period <= maxPeriod; period++ {
+		currentState = currentState.GetNextState()
+		if currentState.String() == shape {
+			nextRowOffset, nextColOffset := currentState.GetOffset()
+			return period, nextRowOffset - rowOffset, nextColOffset - colOffset, nil
+		}
+	}
+
+	return 0, 0, 0, ErrNotPeriodic
+}
+
+// Encode classifies the pattern of cellState and returns its canonical
+// apgcode, the preferred code over every phase and orientation.
+func Encode(cellState *cell.CellState, maxPeriod int) (string, error) {
+	period, rowShift, colShift, err := Classify(cellState, maxPeriod)
+	if err != nil {
+		return "", err
+	}
+
+	var prefix string
+	switch {
+	case rowShift != 0 || colShift != 0:
+		prefix = SpaceshipPrefix + strconv.Itoa(period)
+	case period == 1:
+		prefix = StillLifePrefix + strconv.Itoa(cellState.GetPopulation())
+	default:
+		prefix = OscillatorPrefix + strconv.Itoa(period)
+	}
+
+	phases := make([]*cell.CellState, period)
+	phases[0] = cellState
+	for i := 1; i < period; i++ {
+		phases[i] = phases[i-1].GetNextState()
+	}
+
+	return prefix + prefixSeparator + encodeCanonical(phases), nil
+}
+
+// EncodeGeneration is Encode for a generation evolving under cellRule.
+func EncodeGeneration(generation [][]bool, cellRule *rule.Rule, maxPeriod int) (string, error) {
+	cellState, err := cell.NewWithRule(generation, cellRule)
+	if err != nil {
+		return "", err
+	}
+
+	return Encode(cellState, maxPeriod)
+}
+
+// Decode returns the trimmed generation described by code, in the phase
+// and orientation the code was made from.
+func Decode(code string) ([][]bool, error) {
+	separator := strings.Index(code, prefixSeparator)
+	if separator < 0 {
+		return nil, ErrInvalidCode
+	}
+	prefix, wechsler := code[:separator], code[separator+1:]
+
+	if len(prefix) <= len(StillLifePrefix) {
+		return nil, ErrInvalidCode
+	}
+	kind := prefix[:len(StillLifePrefix)]
+	if kind != StillLifePrefix && kind != OscillatorPrefix && kind != SpaceshipPrefix {
+		return nil, ErrInvalidCode
+	}
+	number, err := strconv.Atoi(prefix[len(StillLifePrefix):])
+	if err != nil || number < 1 {
+		return nil, ErrInvalidCode
+	}
+
+	generation, err := decodeWechsler(wechsler)
+	if err != nil {
+		return nil, err
+	}
+	cellState, err := cell.New(generation)
+	if err != nil {
+		return nil, err
+	}
+	population := cellState.GetPopulation()
+	if population == 0 || (kind == StillLifePrefix && population != number) {
+		return nil, ErrInvalidCode
+	}
+
+	return cellState.GetGeneration(), nil
+}
+
+func encodeCanonical(phases []*cell.CellState) string {
+	canonical := ""
+	for _, phase := range phases {
+		for _, orientation := range transform.Orientations(phase.GetGeneration()) {
+			code := encodeWechsler(orientation)
+			if canonical == "" || isPreferred(code, canonical) {
+				canonical = code
+			}
+		}
+	}
+
+	return canonical
+}
+
+// isPreferred follows apgsearch: the shorter code wins, ties go to the
+// lexicographically smaller one.
+func isPreferred(code, other string) bool {
+	if len(code) != len(other) {
+		return len(code) < len(other)
+	}
+	return code < other
+}
+
+func encodeWechsler(generation [][]bool) string {
+	var buffer bytes.Buffer
+	height := len(generation)
+	width := len(generation[0])
+	for top := 0; top < height; top += stripHeight {
+		if top > 0 {
+			buffer.WriteByte(stripSeparator)
+		}
+
+		numOfZeros := 0
+		for col := 0; col < width; col++ {
+			value := 0
+			for bit := 0; bit < stripHeight && top+bit < height; bit++ {
+				if generation[top+bit][col] {
+					value |= 1 << uint(bit)
+				}
+			}
+			if value == 0 {
+				numOfZeros++
+				continue
+			}
+
+			writeZeros(&buffer, numOfZeros)
+			numOfZeros = 0
+			buffer.WriteByte(columnDigits[value])
+		}
+	}
+
+	return buffer.String()
+}
+
+func writeZeros(buffer *bytes.Buffer, numOfZeros int) {
+	for numOfZeros > 0 {
+		switch {
+		case numOfZeros == 1:
+			buffer.WriteByte(columnDigits[0])
+			numOfZeros = 0
+		case numOfZeros == 2:
+			buffer.WriteByte(twoZeros)
+			numOfZeros = 0
+		case numOfZeros == 3:
+			buffer.WriteByte(threeZeros)
+			numOfZeros = 0
+		default:
+			run := numOfZeros
+			if run > maxManyZeros {
+				run = maxManyZeros
+			}
+			buffer.WriteByte(manyZeros)
+			buffer.WriteByte(zeroRunDigits[run-minManyZeros])
+			numOfZeros -= run
+		}
+	}
+}
+
+func decodeWechsler(wechsler string) ([][]bool, error) {
+	if wechsler == "" {
+		return nil, ErrInvalidCode
+	}
+
+	strips := strings.Split(wechsler, string(stripSeparator))
+	columns := make([][]int, len(strips))
+	width := 0
+	for i, strip := range strips {
+		for j := 0; j < len(strip); j++ {
+			switch strip[j] {
+			case twoZeros:
+				columns[i] = append(columns[i], 0, 0)
+			case threeZeros:
+				columns[i] = append(columns[i], 0, 0, 0)
+			case manyZeros:
+				j++
+				if j == len(strip) || strings.IndexByte(zeroRunDigits, strip[j]) < 0 {
+					return nil, ErrInvalidCode
+				}
+				columns[i] = append(columns[i], make([]int, minManyZeros+strings.IndexByte(zeroRunDigits, strip[j]))...)
+			default:
+				value := strings.IndexByte(columnDigits, strip[j])
+				if value < 0 {
+					return nil, ErrInvalidCode
+				}
+				columns[i] = append(columns[i], value)
+			}
+		}
+		if len(columns[i]) > width {
+			width = len(columns[i])
+		}
+	}
+	if width == 0 {
+		return nil, ErrInvalidCode
+	}
+
+	generation := make([][]bool, len(strips)*stripHeight)
+	for row := 0; row < len(generation); row++ {
+		generation[row] = make([]bool, width)
+	}
+	for i, strip := range columns {
+		for col, value := range strip {
+			for bit := 0; bit < stripHeight; bit++ {
+				generation[i*stripHeight+bit][col] = value&(1<<uint(bit)) != 0
+			}
+		}
+	}
+
+	return generation, nil
+}
@@ -0,0 +1,252 @@
+package apgcode_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/apgcode"
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	blockGeneration = [][]bool{
+		{true, true},
+		{true, true},
+	}
+	blinkerGeneration = [][]bool{
+		{true, true, true},
+	}
+	gliderGeneration = [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+	beehiveGeneration = [][]bool{
+		{false, true, true, false},
+		{true, false, false, true},
+		{false, true, true, false},
+	}
+	tubGeneration = [][]bool{
+		{false, true, false},
+		{true, false, true},
+		{false, true, false},
+	}
+	rPentominoGeneration = [][]bool{
+		{false, true, true},
+		{true, true, false},
+		{false, true, false},
+	}
+)
+
+func TestClassify(t *testing.T) {
+	t.Run("should return error for nil cell state", func(t *testing.T) {
+		var expectedError = apgcode.NilCellStateError
+
+		_, _, _, actualError := apgcode.Classify(nil, 1)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for max period less than one", func(t *testing.T) {
+		cellState, _ := cell.New(blockGeneration)
+		var expectedError = apgcode.InvalidMaxPeriodError
+
+		_, _, _, actualError := apgcode.Classify(cellState, 0)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for extinct cell state", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{{true}})
+		var expectedError = apgcode.ExtinctCellStateError
+
+		_, _, _, actualError := apgcode.Classify(cellState.GetNextState(), 1)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for pattern not periodic within max period", func(t *testing.T) {
+		cellState, _ := cell.New(rPentominoGeneration)
+		var expectedError = apgcode.NotPeriodicError
+
+		_, _, _, actualError := apgcode.Classify(cellState, 30)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return period and shift of patterns", func(t *testing.T) {
+		testCases := []struct {
+			generation       [][]bool
+			expectedPeriod   int
+			expectedRowShift int
+			expectedColShift int
+		}{
+			{blockGeneration, 1, 0, 0},
+			{blinkerGeneration, 2, 0, 0},
+			{gliderGeneration, 4, 1, 1},
+		}
+
+		for _, testCase := range testCases {
+			cellState, _ := cell.New(testCase.generation)
+
+			actualPeriod, actualRowShift, actualColShift, actualError := apgcode.Classify(cellState, 30)
+
+			assert.Equal(t, testCase.expectedPeriod, actualPeriod)
+			assert.Equal(t, testCase.expectedRowShift, actualRowShift)
+			assert.Equal(t, testCase.expectedColShift, actualColShift)
+			assert.Nil(t, actualError)
+		}
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("should return error for nil cell state", func(t *testing.T) {
+		var expectedError = apgcode.NilCellStateError
+
+		actualCode, actualError := apgcode.Encode(nil, 1)
+
+		assert.Empty(t, actualCode)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return canonical code of known patterns", func(t *testing.T) {
+		testCases := []struct {
+			generation   [][]bool
+			expectedCode string
+		}{
+			{blockGeneration, "xs4_33"},
+			{tubGeneration, "xs4_252"},
+			{beehiveGeneration, "xs6_696"},
+			{blinkerGeneration, "xp2_7"},
+			{gliderGeneration, "xq4_153"},
+		}
+
+		for _, testCase := range testCases {
+			cellState, _ := cell.New(testCase.generation)
+
+			actualCode, actualError := apgcode.Encode(cellState, 30)
+
+			assert.Equal(t, testCase.expectedCode, actualCode)
+			assert.Nil(t, actualError)
+		}
+	})
+
+	t.Run("should return same code for every phase and orientation", func(t *testing.T) {
+		rotatedGlider := [][]bool{
+			{true, true, true},
+			{true, false, false},
+			{false, true, false},
+		}
+		cellState, _ := cell.New(rotatedGlider)
+		var expectedCode = "xq4_153"
+
+		for generation := 0; generation < 4; generation++ {
+			actualCode, actualError := apgcode.Encode(cellState, 30)
+
+			assert.Equal(t, expectedCode, actualCode)
+			assert.Nil(t, actualError)
+			cellState = cellState.GetNextState()
+		}
+	})
+}
+
+func TestEncodeGeneration(t *testing.T) {
+	t.Run("should return error for nil rule", func(t *testing.T) {
+		var expectedError = cell.NilRuleError
+
+		actualCode, actualError := apgcode.EncodeGeneration(blockGeneration, nil, 30)
+
+		assert.Empty(t, actualCode)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return code of generation under rule", func(t *testing.T) {
+		cellState, _ := cell.New(gliderGeneration)
+		var expectedCode = "xq4_153"
+
+		actualCode, actualError := apgcode.EncodeGeneration(cellState.GetGeneration(), cellState.GetRule(), 30)
+
+		assert.Equal(t, expectedCode, actualCode)
+		assert.Nil(t, actualError)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("should return nil and error for invalid codes", func(t *testing.T) {
+		invalidCodes := []string{
+			"",
+			"xs4",
+			"x4_33",
+			"xz4_33",
+			"xs_33",
+			"xs0_33",
+			"xs5_33",
+			"xp2_",
+			"xp2_0",
+			"xp2_7!",
+			"xp2_y",
+		}
+		var expectedError = apgcode.InvalidCodeError
+
+		for _, code := range invalidCodes {
+			actualGeneration, actualError := apgcode.Decode(code)
+
+			assert.Nil(t, actualGeneration, code)
+			assert.EqualError(t, actualError, expectedError, code)
+		}
+	})
+
+	t.Run("should return generation of known codes", func(t *testing.T) {
+		testCases := []struct {
+			code               string
+			expectedGeneration [][]bool
+		}{
+			{"xs4_33", blockGeneration},
+			{"xp2_7", [][]bool{{true}, {true}, {true}}},
+			{"xq4_153", [][]bool{
+				{true, true, true},
+				{false, false, true},
+				{false, true, false},
+			}},
+		}
+
+		for _, testCase := range testCases {
+			actualGeneration, actualError := apgcode.Decode(testCase.code)
+
+			assert.EqualValues(t, testCase.expectedGeneration, actualGeneration)
+			assert.Nil(t, actualError)
+		}
+	})
+
+	t.Run("should return generation with zero runs and strips", func(t *testing.T) {
+		var code = "xs12_33y033zw33"
+		expectedGeneration := [][]bool{
+			{true, true, false, false, false, false, true, true},
+			{true, true, false, false, false, false, true, true},
+			{false, false, false, false, false, false, false, false},
+			{false, false, false, false, false, false, false, false},
+			{false, false, false, false, false, false, false, false},
+			{false, false, true, true, false, false, false, false},
+			{false, false, true, true, false, false, false, false},
+		}
+
+		actualGeneration, actualError := apgcode.Decode(code)
+
+		assert.EqualValues(t, expectedGeneration, actualGeneration)
+		assert.Nil(t, actualError)
+	})
+
+	t.Run("should return generation that encodes back to the same code", func(t *testing.T) {
+		codes := []string{"xs4_33", "xs4_252", "xs6_696", "xs8_6996", "xp2_7", "xq4_153"}
+
+		for _, code := range codes {
+			generation, _ := apgcode.Decode(code)
+			cellState, _ := cell.New(generation)
+
+			actualCode, actualError := apgcode.Encode(cellState, 30)
+
+			assert.Equal(t, code, actualCode)
+			assert.Nil(t, actualError)
+		}
+	})
+}
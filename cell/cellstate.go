@@ -3,43 +3,149 @@ package cell
 import (
 	"bytes"
 	"errors"
+	"strings"
+
+	"github.com/irainia/gameoflife-go/rule"
 )
 
 const (
 	GenerationNilError               = "generation passed is nil"
 	GenerationEmptyError             = "generation passed is empty"
 	GenerationShapeNotRectangleError = "generation shape is not rectangle"
+	NilRuleError                     = "rule passed is nil"
+	StateOutOfRangeError             = "cell state is out of range of the rule"
+)
+
+var (
+	ErrGenerationNil               = errors.New(GenerationNilError)
+	ErrGenerationEmpty             = errors.New(GenerationEmptyError)
+	ErrGenerationShapeNotRectangle = errors.New(GenerationShapeNotRectangleError)
+	ErrNilRule                     = errors.New(NilRuleError)
+	ErrStateOutOfRange             = errors.New(StateOutOfRangeError)
+)
+
+const (
+	// StateCharacters holds the character of each state, so a state is
+	// written as StateCharacters[state]: '-' is dead, 'o' is alive and the
+	// rest are the states after, e.g. the decaying states of Generations.
+	StateCharacters = "-o23456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	// NeighborhoodSide is the side of the neighborhoods GetNextStates is
+	// indexed by, NumOfAssignments the number of ways they can be alive.
+	NeighborhoodSide = 3
+	NumOfAssignments = 1 << (NeighborhoodSide * NeighborhoodSide)
+
+	expansionEachSide = 2
 )
 
 type CellState struct {
-	currentGeneration [][]bool
+	currentGeneration [][]uint8
+	rule              *rule.Rule
+
+	rowOffset int
+	colOffset int
+
+	ages [][]int
+	heat *heatMap
 }
 
+// GetGeneration returns the living cells, those in state 1, in a grid of
+// the same shape as GetStates.
 func (cellState *CellState) GetGeneration() [][]bool {
+	generation := make([][]bool, len(cellState.currentGeneration))
+	for i := 0; i < len(cellState.currentGeneration); i++ {
+		generation[i] = make([]bool, len(cellState.currentGeneration[i]))
+		for j := 0; j < len(cellState.currentGeneration[i]); j++ {
+			generation[i][j] = cellState.currentGeneration[i][j] == rule.Alive
+		}
+	}
+
+	return generation
+}
+
+func (cellState *CellState) GetStates() [][]uint8 {
 	return duplicateGeneration(cellState.currentGeneration)
 }
 
+func (cellState *CellState) GetRule() *rule.Rule {
+	return cellState.rule
+}
+
+func (cellState *CellState) GetOffset() (int, int) {
+	return cellState.rowOffset, cellState.colOffset
+}
+
+func (cellState *CellState) GetPopulation() int {
+	population := 0
+	for i := 0; i < len(cellState.currentGeneration); i++ {
+		for j := 0; j < len(cellState.currentGeneration[i]); j++ {
+			if cellState.currentGeneration[i][j] == rule.Alive {
+				population++
+			}
+		}
+	}
+
+	return population
+}
+
+func (cellState *CellState) IsAlive(row, col int) bool {
+	return cellState.GetState(row, col) == rule.Alive
+}
+
+func (cellState *CellState) GetState(row, col int) uint8 {
+	i := row - cellState.rowOffset
+	j := col - cellState.colOffset
+	if i < 0 || i >= len(cellState.currentGeneration) {
+		return rule.Dead
+	}
+	if j < 0 || j >= len(cellState.currentGeneration[i]) {
+		return rule.Dead
+	}
+
+	return cellState.currentGeneration[i][j]
+}
+
 func (cellState *CellState) GetNextState() *CellState {
-	currentGeneration := cellState.GetGeneration()
-	expandedCurrentGeneration := expandGeneration(currentGeneration, 2)
-	nextGeneration := makeNextGeneration(expandedCurrentGeneration)
+	currentGeneration := cellState.currentGeneration
+	if len(currentGeneration) == 0 {
+		nextState := *cellState
+		return &nextState
+	}
+
+	// cells as far as the radius out can be born, and counting their
+	// neighbors needs as many cells again
+	expansion := expansionEachSide * cellState.rule.GetRadius()
+	expandedCurrentGeneration := expandGeneration(currentGeneration, expansion)
+	nextGeneration := makeNextGeneration(expandedCurrentGeneration, cellState.rule)
+	trimmedGeneration, minRowIndex, minColIndex := trimGeneration(nextGeneration)
 
 	nextState := CellState{
-		currentGeneration: nextGeneration,
+		currentGeneration: trimmedGeneration,
+		rule:              cellState.rule,
+		rowOffset:         cellState.rowOffset + minRowIndex - expansion,
+		colOffset:         cellState.colOffset + minColIndex - expansion,
 	}
+	cellState.track(&nextState)
 	return &nextState
 }
 
+func (cellState *CellState) IsEqual(other *CellState) bool {
+	if other == nil {
+		return false
+	}
+	if cellState.rowOffset != other.rowOffset || cellState.colOffset != other.colOffset {
+		return false
+	}
+
+	return isGenerationEqual(cellState.currentGeneration, other.currentGeneration)
+}
+
 func (cellState *CellState) String() string {
-	currentGeneration := cellState.GetGeneration()
+	currentGeneration := cellState.currentGeneration
 	var buffer bytes.Buffer
 	for i := 0; i < len(currentGeneration); i++ {
 		for j := 0; j < len(currentGeneration[i]); j++ {
-			if currentGeneration[i][j] {
-				buffer.WriteString("o")
-			} else {
-				buffer.WriteString("-")
-			}
+			buffer.WriteByte(StateCharacters[currentGeneration[i][j]])
 		}
 
 		if i < len(currentGeneration)-1 {
@@ -50,54 +156,154 @@ func (cellState *CellState) String() string {
 	return buffer.String()
 }
 
+// ParseState returns the state written as character, see StateCharacters.
+func ParseState(character byte) (uint8, bool) {
+	state := strings.IndexByte(StateCharacters, character)
+	if state < 0 {
+		return rule.Dead, false
+	}
+
+	return uint8(state), true
+}
+
+// GetNextStates returns whether the centre of each three by three
+// neighborhood is alive after one step of cellRule, bit i*3+j of the index
+// being the cell at row i and column j.
+func GetNextStates(cellRule *rule.Rule) ([]bool, error) {
+	nextStates := make([]bool, NumOfAssignments)
+	for assignment := 1; assignment < NumOfAssignments; assignment++ {
+		generation := make([][]bool, NeighborhoodSide)
+		for i := 0; i < NeighborhoodSide; i++ {
+			generation[i] = make([]bool, NeighborhoodSide)
+			for j := 0; j < NeighborhoodSide; j++ {
+				generation[i][j] = assignment&(1<<uint(i*NeighborhoodSide+j)) != 0
+			}
+		}
+
+		cellState, err := NewWithRule(generation, cellRule)
+		if err != nil {
+			return nil, err
+		}
+		nextStates[assignment] = cellState.GetNextState().IsAlive(1, 1)
+	}
+
+	return nextStates, nil
+}
+
 func New(initialGeneration [][]bool) (*CellState, error) {
-	isValid, err := isGenerationValid(initialGeneration)
+	return NewWithRule(initialGeneration, rule.Default())
+}
+
+func NewWithRule(initialGeneration [][]bool, cellRule *rule.Rule) (*CellState, error) {
+	return NewWithOffset(initialGeneration, cellRule, 0, 0)
+}
+
+func NewWithOffset(initialGeneration [][]bool, cellRule *rule.Rule, rowOffset, colOffset int) (*CellState, error) {
+	return NewWithStates(toStates(initialGeneration), cellRule, rowOffset, colOffset)
+}
+
+// NewWithStates is NewWithOffset for rules with more than two states, each
+// cell holding a state below cellRule.GetNumOfStates().
+func NewWithStates(initialStates [][]uint8, cellRule *rule.Rule, rowOffset, colOffset int) (*CellState, error) {
+	isValid, err := isGenerationValid(initialStates)
 	if !isValid || err != nil {
 		return nil, err
 	}
+	if cellRule == nil {
+		return nil, ErrNilRule
+	}
+	for i := 0; i < len(initialStates); i++ {
+		for j := 0; j < len(initialStates[i]); j++ {
+			if int(initialStates[i][j]) >= cellRule.GetNumOfStates() {
+				return nil, ErrStateOutOfRange
+			}
+		}
+	}
 
+	trimmedGeneration, minRowIndex, minColIndex := trimGeneration(initialStates)
 	cellState := CellState{
-		currentGeneration: trimGeneration(initialGeneration),
+		currentGeneration: trimmedGeneration,
+		rule:              cellRule,
+		rowOffset:         rowOffset + minRowIndex,
+		colOffset:         colOffset + minColIndex,
 	}
 	return &cellState, nil
 }
 
-func isGenerationValid(generation [][]bool) (bool, error) {
+func isGenerationValid(generation [][]uint8) (bool, error) {
 	if generation == nil {
-		return false, errors.New(GenerationNilError)
+		return false, ErrGenerationNil
 	}
 	if len(generation) == 0 {
-		return false, errors.New(GenerationEmptyError)
+		return false, ErrGenerationEmpty
 	}
 
 	colLength := len(generation[0])
 	for i := 0; i < len(generation); i++ {
 		if len(generation[i]) != colLength {
-			return false, errors.New(GenerationShapeNotRectangleError)
+			return false, ErrGenerationShapeNotRectangle
 		}
 	}
 
 	return true, nil
 }
 
-func duplicateGeneration(originalGeneration [][]bool) [][]bool {
+func toStates(generation [][]bool) [][]uint8 {
+	if generation == nil {
+		return nil
+	}
+
+	states := make([][]uint8, len(generation))
+	for i := 0; i < len(generation); i++ {
+		states[i] = make([]uint8, len(generation[i]))
+		for j := 0; j < len(generation[i]); j++ {
+			if generation[i][j] {
+				states[i][j] = rule.Alive
+			}
+		}
+	}
+
+	return states
+}
+
+func isGenerationEqual(generation, other [][]uint8) bool {
+	if len(generation) != len(other) {
+		return false
+	}
+	for i := 0; i < len(generation); i++ {
+		if len(generation[i]) != len(other[i]) {
+			return false
+		}
+		for j := 0; j < len(generation[i]); j++ {
+			if generation[i][j] != other[i][j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func duplicateGeneration(originalGeneration [][]uint8) [][]uint8 {
 	if !isLivingCellExist(originalGeneration) {
-		return make([][]bool, 0)
+		return make([][]uint8, 0)
 	}
 
-	duplicatedGeneration := make([][]bool, len(originalGeneration))
+	duplicatedGeneration := make([][]uint8, len(originalGeneration))
 	for i := 0; i < len(originalGeneration); i++ {
-		duplicatedGeneration[i] = make([]bool, len(originalGeneration[i]))
+		duplicatedGeneration[i] = make([]uint8, len(originalGeneration[i]))
 		copy(duplicatedGeneration[i], originalGeneration[i])
 	}
 
 	return duplicatedGeneration
 }
 
-func isLivingCellExist(generation [][]bool) bool {
+// isLivingCellExist also counts the decaying states, which still take
+// part in the pattern.
+func isLivingCellExist(generation [][]uint8) bool {
 	for i := 0; i < len(generation); i++ {
 		for j := 0; j < len(generation[i]); j++ {
-			if generation[i][j] {
+			if generation[i][j] != rule.Dead {
 				return true
 			}
 		}
@@ -106,9 +312,9 @@ func isLivingCellExist(generation [][]bool) bool {
 	return false
 }
 
-func trimGeneration(originalGeneration [][]bool) [][]bool {
+func trimGeneration(originalGeneration [][]uint8) ([][]uint8, int, int) {
 	if !isLivingCellExist(originalGeneration) {
-		return make([][]bool, 0)
+		return make([][]uint8, 0), 0, 0
 	}
 
 	minRowIndex := len(originalGeneration)
@@ -117,7 +323,7 @@ func trimGeneration(originalGeneration [][]bool) [][]bool {
 	maxColIndex := 0
 	for i := 0; i < len(originalGeneration); i++ {
 		for j := 0; j < len(originalGeneration[i]); j++ {
-			if originalGeneration[i][j] {
+			if originalGeneration[i][j] != rule.Dead {
 				if i < minRowIndex {
 					minRowIndex = i
 				}
@@ -134,21 +340,19 @@ func trimGeneration(originalGeneration [][]bool) [][]bool {
 		}
 	}
 
-	trimmedGeneration := make([][]bool, maxRowIndex-minRowIndex+1)
+	trimmedGeneration := make([][]uint8, maxRowIndex-minRowIndex+1)
 	for i := minRowIndex; i <= maxRowIndex; i++ {
-		trimmedGeneration[i-minRowIndex] = make([]bool, maxColIndex-minColIndex+1)
-		for j := minColIndex; j <= maxColIndex; j++ {
-			trimmedGeneration[i-minRowIndex][j-minColIndex] = originalGeneration[i][j]
-		}
+		trimmedGeneration[i-minRowIndex] = make([]uint8, maxColIndex-minColIndex+1)
+		copy(trimmedGeneration[i-minRowIndex], originalGeneration[i][minColIndex:maxColIndex+1])
 	}
 
-	return trimmedGeneration
+	return trimmedGeneration, minRowIndex, minColIndex
 }
 
-func expandGeneration(originalGeneration [][]bool, additionalEachSide int) [][]bool {
-	expandedGeneration := make([][]bool, len(originalGeneration)+additionalEachSide*2)
+func expandGeneration(originalGeneration [][]uint8, additionalEachSide int) [][]uint8 {
+	expandedGeneration := make([][]uint8, len(originalGeneration)+additionalEachSide*2)
 	for i := 0; i < len(expandedGeneration); i++ {
-		expandedGeneration[i] = make([]bool, len(originalGeneration[0])+additionalEachSide*2)
+		expandedGeneration[i] = make([]uint8, len(originalGeneration[0])+additionalEachSide*2)
 		if i >= additionalEachSide && i < len(expandedGeneration)-additionalEachSide {
 			copy(expandedGeneration[i][additionalEachSide:], originalGeneration[i-additionalEachSide])
 		}
@@ -157,41 +361,244 @@ func expandGeneration(originalGeneration [][]bool, additionalEachSide int) [][]b
 	return expandedGeneration
 }
 
-func makeEmptyGeneration(row, column int) [][]bool {
-	emptyGeneration := make([][]bool, row)
+func makeEmptyGeneration(row, column int) [][]uint8 {
+	emptyGeneration := make([][]uint8, row)
 	for i := 0; i < row; i++ {
-		emptyGeneration[i] = make([]bool, column)
+		emptyGeneration[i] = make([]uint8, column)
 	}
 
 	return emptyGeneration
 }
 
-func makeNextGeneration(currentGeneration [][]bool) [][]bool {
+func makeNextGeneration(currentGeneration [][]uint8, cellRule *rule.Rule) [][]uint8 {
+	if cellRule.IsTable() {
+		return makeNextGenerationByTable(currentGeneration, cellRule)
+	}
+	if cellRule.IsLargerThanLife() {
+		return makeNextGenerationByRange(currentGeneration, cellRule)
+	}
+	if weights := cellRule.GetWeights(); weights != nil {
+		return makeNextGenerationByWeights(currentGeneration, cellRule, weights)
+	}
+	if !cellRule.IsTotalistic() {
+		return makeNextGenerationByNeighborhood(currentGeneration, cellRule)
+	}
+
 	row := len(currentGeneration)
 	column := len(currentGeneration[0])
 
 	newGeneration := makeEmptyGeneration(row, column)
 
-	for i := 1; i < len(currentGeneration)-1; i++ {
-		for j := 1; j < len(currentGeneration[i])-1; j++ {
-			numOfNeighbors := 0
+	// each column sum covers the three rows around i, so the neighbors of a
+	// cell are the sums of its own and both adjacent columns minus itself
+	columnSums := make([]int, column)
+	for i := 1; i < row-1; i++ {
+		for j := 0; j < column; j++ {
+			columnSums[j] = 0
 			for p := i - 1; p <= i+1; p++ {
-				for q := j - 1; q <= j+1; q++ {
-					if p == i && q == j {
-						continue
-					}
+				if currentGeneration[p][j] == rule.Alive {
+					columnSums[j]++
+				}
+			}
+		}
+
+		for j := 1; j < column-1; j++ {
+			numOfNeighbors := columnSums[j-1] + columnSums[j] + columnSums[j+1]
+			state := currentGeneration[i][j]
+			if state == rule.Alive {
+				numOfNeighbors--
+			} else if state == rule.Dead && numOfNeighbors == 0 {
+				continue
+			}
+			newGeneration[i][j] = cellRule.GetNextState(state, numOfNeighbors)
+		}
+	}
+
+	return newGeneration
+}
 
-					if currentGeneration[p][q] {
-						numOfNeighbors++
+// makeNextGenerationByNeighborhood looks up the next state of each cell by
+// which of its neighbors are alive, for rules that are not totalistic.
+func makeNextGenerationByNeighborhood(currentGeneration [][]uint8, cellRule *rule.Rule) [][]uint8 {
+	row := len(currentGeneration)
+	column := len(currentGeneration[0])
+
+	newGeneration := makeEmptyGeneration(row, column)
+	for i := 1; i < row-1; i++ {
+		above, current, below := currentGeneration[i-1], currentGeneration[i], currentGeneration[i+1]
+		for j := 1; j < column-1; j++ {
+			var neighborhood uint8
+			for bit, state := range [8]uint8{
+				above[j-1], above[j], above[j+1],
+				current[j-1], current[j+1],
+				below[j-1], below[j], below[j+1],
+			} {
+				if state == rule.Alive {
+					neighborhood |= 1 << uint(bit)
+				}
+			}
+			if neighborhood == 0 && current[j] == rule.Dead {
+				continue
+			}
+			newGeneration[i][j] = cellRule.GetNextStateByNeighborhood(current[j], neighborhood)
+		}
+	}
+
+	return newGeneration
+}
+
+// makeNextGenerationByWeights sums the weights of the living cells under
+// the mask of a weighted rule, the mask centred on each cell in turn.
+func makeNextGenerationByWeights(currentGeneration [][]uint8, cellRule *rule.Rule, weights [][]int) [][]uint8 {
+	row := len(currentGeneration)
+	column := len(currentGeneration[0])
+	radius := len(weights) / 2
+
+	newGeneration := makeEmptyGeneration(row, column)
+	for i := radius; i < row-radius; i++ {
+		for j := radius; j < column-radius; j++ {
+			count := 0
+			for p := 0; p < len(weights); p++ {
+				for q := 0; q < len(weights[p]); q++ {
+					if weights[p][q] != 0 && currentGeneration[i+p-radius][j+q-radius] == rule.Alive {
+						count += weights[p][q]
 					}
 				}
 			}
 
-			if numOfNeighbors == 2 && currentGeneration[i][j] || numOfNeighbors == 3 {
-				newGeneration[i][j] = true
+			state := currentGeneration[i][j]
+			if state == rule.Dead && count == 0 {
+				continue
+			}
+			newGeneration[i][j] = cellRule.GetNextState(state, count)
+		}
+	}
+
+	return newGeneration
+}
+
+// makeNextGenerationByTable looks up the next state of each cell by its own
+// and its neighbors' states in the table of cellRule.
+func makeNextGenerationByTable(currentGeneration [][]uint8, cellRule *rule.Rule) [][]uint8 {
+	row := len(currentGeneration)
+	column := len(currentGeneration[0])
+	neighbors := cellRule.GetNeighbors()
+
+	newGeneration := makeEmptyGeneration(row, column)
+	states := make([]uint8, len(neighbors)+1)
+	for i := 1; i < row-1; i++ {
+		for j := 1; j < column-1; j++ {
+			states[0] = currentGeneration[i][j]
+			isEmpty := states[0] == rule.Dead
+			for k, neighbor := range neighbors {
+				states[k+1] = currentGeneration[i+neighbor[0]][j+neighbor[1]]
+				isEmpty = isEmpty && states[k+1] == rule.Dead
+			}
+			if isEmpty {
+				continue
+			}
+			newGeneration[i][j] = cellRule.GetNextStateByTable(states)
+		}
+	}
+
+	return newGeneration
+}
+
+// makeNextGenerationByRange counts the living cells within the radius of a
+// Larger than Life rule with a summed-area table, so each count takes the
+// same time whatever the radius. The von Neumann diamond is a square once
+// the grid is turned by 45 degrees, which is what its table is made of.
+func makeNextGenerationByRange(currentGeneration [][]uint8, cellRule *rule.Rule) [][]uint8 {
+	row := len(currentGeneration)
+	column := len(currentGeneration[0])
+	radius := cellRule.GetRadius()
+	isVonNeumann := cellRule.GetNeighborhood() == rule.VonNeumann
+
+	var table [][]int
+	if isVonNeumann {
+		table = makeRotatedTable(currentGeneration)
+	} else {
+		table = makeSummedAreaTable(currentGeneration)
+	}
+
+	newGeneration := makeEmptyGeneration(row, column)
+	for i := radius; i < row-radius; i++ {
+		for j := radius; j < column-radius; j++ {
+			var count int
+			if isVonNeumann {
+				// cell (i, j) is at (i+j, i-j+column-1) on the turned grid
+				u, v := i+j, i-j+column-1
+				count = sumArea(table, u-radius, v-radius, u+radius, v+radius)
+			} else {
+				count = sumArea(table, i-radius, j-radius, i+radius, j+radius)
+			}
+
+			state := currentGeneration[i][j]
+			if state == rule.Alive && !cellRule.IsCenterIncluded() {
+				count--
+			} else if state == rule.Dead && count == 0 {
+				continue
 			}
+			newGeneration[i][j] = cellRule.GetNextState(state, count)
 		}
 	}
 
-	return trimGeneration(newGeneration)
+	return newGeneration
+}
+
+// makeSummedAreaTable returns table where table[i][j] is the number of
+// living cells above row i and left of column j.
+func makeSummedAreaTable(generation [][]uint8) [][]int {
+	row := len(generation)
+	column := len(generation[0])
+
+	table := make([][]int, row+1)
+	table[0] = make([]int, column+1)
+	for i := 0; i < row; i++ {
+		table[i+1] = make([]int, column+1)
+		for j := 0; j < column; j++ {
+			table[i+1][j+1] = table[i+1][j] + table[i][j+1] - table[i][j]
+			if generation[i][j] == rule.Alive {
+				table[i+1][j+1]++
+			}
+		}
+	}
+
+	return table
+}
+
+// makeRotatedTable is makeSummedAreaTable of generation turned by 45
+// degrees, cell (i, j) moving to (i+j, i-j+column-1).
+func makeRotatedTable(generation [][]uint8) [][]int {
+	row := len(generation)
+	column := len(generation[0])
+	side := row + column - 1
+
+	rotated := makeEmptyGeneration(side, side)
+	for i := 0; i < row; i++ {
+		for j := 0; j < column; j++ {
+			rotated[i+j][i-j+column-1] = generation[i][j]
+		}
+	}
+
+	return makeSummedAreaTable(rotated)
+}
+
+// sumArea returns the number of living cells from row top to bottom and
+// column left to right of table, both inclusive and clamped to the grid.
+func sumArea(table [][]int, top, left, bottom, right int) int {
+	top, left = clamp(top, len(table)-1), clamp(left, len(table[0])-1)
+	bottom, right = clamp(bottom+1, len(table)-1), clamp(right+1, len(table[0])-1)
+
+	return table[bottom][right] - table[top][right] - table[bottom][left] + table[top][left]
+}
+
+func clamp(value, max int) int {
+	if value < 0 {
+		return 0
+	}
+	if value > max {
+		return max
+	}
+	return value
 }
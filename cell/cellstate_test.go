@@ -2,9 +2,11 @@ package cell_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/rule"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,6 +74,15 @@ func TestNew(t *testing.T) {
 			t.Errorf("expected: %s -- actual: %s", expectedError.Error(), actualError.Error())
 		}
 	})
+
+	t.Run("should return error telling invalid generations apart", func(t *testing.T) {
+		_, nilError := cell.New(nil)
+		_, emptyError := cell.New([][]bool{})
+
+		assert.True(t, errors.Is(nilError, cell.ErrGenerationNil))
+		assert.True(t, errors.Is(emptyError, cell.ErrGenerationEmpty))
+		assert.False(t, errors.Is(emptyError, cell.ErrGenerationNil))
+	})
 }
 
 func TestGetCurrentGeneration(t *testing.T) {
@@ -280,6 +291,182 @@ func TestGetCurrentGeneration(t *testing.T) {
 	})
 }
 
+func TestNewWithRule(t *testing.T) {
+	t.Run("should return nil and error for nil rule", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{true},
+		}
+		var expectedError = cell.NilRuleError
+
+		actualCellState, actualError := cell.NewWithRule(initialGeneration, nil)
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should use conway rule for new", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{true},
+		}
+		cellState, _ := cell.New(initialGeneration)
+		var expectedRule = rule.Conway
+
+		actualRule := cellState.GetRule()
+
+		assert.Equal(t, expectedRule, actualRule.String())
+	})
+
+	t.Run("should apply the rule to next state", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{true, true},
+		}
+		seeds, _ := rule.New("B2/S")
+		cellState, _ := cell.NewWithRule(initialGeneration, seeds)
+		var expectedGeneration [][]bool = [][]bool{
+			{true, true},
+			{false, false},
+			{true, true},
+		}
+
+		nextState := cellState.GetNextState()
+		actualGeneration := nextState.GetGeneration()
+
+		assert.EqualValues(t, expectedGeneration, actualGeneration)
+		assert.Equal(t, seeds, nextState.GetRule())
+	})
+}
+
+func TestGetOffset(t *testing.T) {
+	t.Run("should return trimmed top left on creation", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{false, false, false},
+			{false, false, true},
+		}
+		cellState, _ := cell.New(initialGeneration)
+
+		actualRow, actualCol := cellState.GetOffset()
+
+		assert.Equal(t, 1, actualRow)
+		assert.Equal(t, 2, actualCol)
+	})
+
+	t.Run("should follow the pattern position on next state", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{true, true, true},
+		}
+		cellState, _ := cell.New(initialGeneration)
+
+		actualRow, actualCol := cellState.GetNextState().GetOffset()
+
+		assert.Equal(t, -1, actualRow)
+		assert.Equal(t, 1, actualCol)
+	})
+
+	t.Run("should move glider one cell diagonally every four generations", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		}
+		cellState, _ := cell.New(initialGeneration)
+
+		for i := 0; i < 4; i++ {
+			cellState = cellState.GetNextState()
+		}
+		actualRow, actualCol := cellState.GetOffset()
+
+		assert.Equal(t, 1, actualRow)
+		assert.Equal(t, 1, actualCol)
+		assert.EqualValues(t, initialGeneration, cellState.GetGeneration())
+	})
+
+	t.Run("should add the given offset on creation", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{false, false},
+			{false, true},
+		}
+		cellState, _ := cell.NewWithOffset(initialGeneration, rule.Default(), -5, 10)
+
+		actualRow, actualCol := cellState.GetOffset()
+
+		assert.Equal(t, -4, actualRow)
+		assert.Equal(t, 11, actualCol)
+	})
+
+	t.Run("should not panic on next state of empty state", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{false},
+		}
+		cellState, _ := cell.New(initialGeneration)
+
+		actualGeneration := cellState.GetNextState().GetGeneration()
+
+		assert.Len(t, actualGeneration, 0)
+	})
+}
+
+func TestGetPopulation(t *testing.T) {
+	t.Run("should count living cells", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		}
+		cellState, _ := cell.New(initialGeneration)
+
+		actualPopulation := cellState.GetPopulation()
+
+		assert.Equal(t, 5, actualPopulation)
+	})
+}
+
+func TestIsAlive(t *testing.T) {
+	t.Run("should use position including offset", func(t *testing.T) {
+		var initialGeneration [][]bool = [][]bool{
+			{false, false},
+			{false, true},
+		}
+		cellState, _ := cell.New(initialGeneration)
+
+		assert.True(t, cellState.IsAlive(1, 1))
+		assert.False(t, cellState.IsAlive(0, 0))
+	})
+
+	t.Run("should return false outside the generation", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{{true}})
+
+		assert.False(t, cellState.IsAlive(-1, 0))
+		assert.False(t, cellState.IsAlive(0, 1))
+	})
+}
+
+func TestIsEqual(t *testing.T) {
+	t.Run("should return false for nil", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{{true}})
+
+		assert.False(t, cellState.IsEqual(nil))
+	})
+
+	t.Run("should return true for the same generation and offset", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{{true, true}, {true, true}})
+
+		assert.True(t, cellState.IsEqual(cellState.GetNextState()))
+	})
+
+	t.Run("should return false for the same generation on different offset", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{{true}})
+		other, _ := cell.New([][]bool{{false, true}})
+
+		assert.False(t, cellState.IsEqual(other))
+	})
+
+	t.Run("should return false for different generation", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{{true, true, true}})
+
+		assert.False(t, cellState.IsEqual(cellState.GetNextState()))
+	})
+}
+
 func TestString(t *testing.T) {
 	t.Run("convert True -> 0", func(t *testing.T) {
 		var initialGeneration [][]bool = [][]bool{
@@ -318,3 +505,356 @@ func TestString(t *testing.T) {
 		assert.Equal(t, expectedString, actualString)
 	})
 }
+
+func TestNewWithStates(t *testing.T) {
+	briansBrain, _ := rule.New("/2/3")
+
+	t.Run("should return nil and error for nil states", func(t *testing.T) {
+		var expectedError = cell.GenerationNilError
+
+		actualCellState, actualError := cell.NewWithStates(nil, briansBrain, 0, 0)
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for nil rule", func(t *testing.T) {
+		var expectedError = cell.NilRuleError
+
+		actualCellState, actualError := cell.NewWithStates([][]uint8{{1}}, nil, 0, 0)
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for state out of range of the rule", func(t *testing.T) {
+		var expectedError = cell.StateOutOfRangeError
+
+		actualCellState, actualError := cell.NewWithStates([][]uint8{{1, 3}}, briansBrain, 0, 0)
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return cell state trimmed around every state", func(t *testing.T) {
+		states := [][]uint8{
+			{0, 0, 0},
+			{0, 2, 1},
+		}
+		expectedStates := [][]uint8{{2, 1}}
+		expectedGeneration := [][]bool{{false, true}}
+
+		actualCellState, actualError := cell.NewWithStates(states, briansBrain, 0, 0)
+
+		assert.Nil(t, actualError)
+		assert.EqualValues(t, expectedStates, actualCellState.GetStates())
+		assert.EqualValues(t, expectedGeneration, actualCellState.GetGeneration())
+		assert.Equal(t, 1, actualCellState.GetPopulation())
+		assert.Equal(t, uint8(2), actualCellState.GetState(1, 1))
+		assert.False(t, actualCellState.IsAlive(1, 1))
+		assert.True(t, actualCellState.IsAlive(1, 2))
+	})
+}
+
+func TestGenerationsRule(t *testing.T) {
+	t.Run("should decay cells that do not survive", func(t *testing.T) {
+		briansBrain, _ := rule.New("/2/3")
+		cellState, _ := cell.NewWithStates([][]uint8{{1, 1}}, briansBrain, 0, 0)
+		expectedString := "oo\n22\noo"
+
+		actualCellState := cellState.GetNextState()
+
+		assert.Equal(t, expectedString, actualCellState.String())
+		rowOffset, colOffset := actualCellState.GetOffset()
+		assert.Equal(t, -1, rowOffset)
+		assert.Equal(t, 0, colOffset)
+	})
+
+	t.Run("should die after the last decaying state", func(t *testing.T) {
+		generationsRule, _ := rule.New("B2/S/C4")
+		cellState, _ := cell.NewWithStates([][]uint8{{2}}, generationsRule, 0, 0)
+
+		firstState := cellState.GetNextState()
+		secondState := firstState.GetNextState()
+
+		assert.Equal(t, "3", firstState.String())
+		assert.Empty(t, secondState.GetStates())
+	})
+
+	t.Run("should not count decaying cells as neighbors", func(t *testing.T) {
+		generationsRule, _ := rule.New("B2/S/C3")
+		cellState, _ := cell.NewWithStates([][]uint8{{1, 2, 1}}, generationsRule, 0, 0)
+		expectedString := "-o-\n2-2\n-o-"
+
+		actualCellState := cellState.GetNextState()
+
+		assert.Equal(t, expectedString, actualCellState.String())
+	})
+}
+
+func TestParseState(t *testing.T) {
+	t.Run("should return state of each state character", func(t *testing.T) {
+		for expectedState := 0; expectedState < len(cell.StateCharacters); expectedState++ {
+			actualState, isValid := cell.ParseState(cell.StateCharacters[expectedState])
+
+			assert.True(t, isValid)
+			assert.Equal(t, uint8(expectedState), actualState)
+		}
+	})
+
+	t.Run("should return false for unknown character", func(t *testing.T) {
+		_, isValid := cell.ParseState('x')
+
+		assert.False(t, isValid)
+	})
+
+	t.Run("should have a character for every state of a rule", func(t *testing.T) {
+		assert.Equal(t, rule.MaxNumOfStates, len(cell.StateCharacters))
+	})
+}
+
+func TestGetNextStates(t *testing.T) {
+	t.Run("should return error for nil rule", func(t *testing.T) {
+		var expectedError = cell.NilRuleError
+
+		actualNextStates, actualError := cell.GetNextStates(nil)
+
+		assert.Nil(t, actualNextStates)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return next state of centre of each neighborhood", func(t *testing.T) {
+		var (
+			blinkerCentre  = 1<<3 | 1<<4 | 1<<5
+			lonelyCentre   = 1 << 4
+			bornCentre     = 1<<0 | 1<<2 | 1<<8
+			crowdedCentre  = 1<<0 | 1<<1 | 1<<2 | 1<<3 | 1<<4
+			numOfNextState = cell.NumOfAssignments
+		)
+
+		actualNextStates, actualError := cell.GetNextStates(rule.Default())
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualNextStates, numOfNextState)
+		assert.False(t, actualNextStates[0])
+		assert.True(t, actualNextStates[blinkerCentre])
+		assert.False(t, actualNextStates[lonelyCentre])
+		assert.True(t, actualNextStates[bornCentre])
+		assert.False(t, actualNextStates[crowdedCentre])
+	})
+}
+
+func TestHenselRule(t *testing.T) {
+	t.Run("should kill block without survival on three adjacent neighbors", func(t *testing.T) {
+		nonTotalistic, _ := rule.New("B3/S23-a")
+		cellState, _ := cell.NewWithRule([][]bool{
+			{true, true},
+			{true, true},
+		}, nonTotalistic)
+
+		actualCellState := cellState.GetNextState()
+
+		assert.Empty(t, actualCellState.GetGeneration())
+	})
+
+	t.Run("should keep block with survival on three adjacent neighbors", func(t *testing.T) {
+		nonTotalistic, _ := rule.New("B3/S23-r")
+		cellState, _ := cell.NewWithRule([][]bool{
+			{true, true},
+			{true, true},
+		}, nonTotalistic)
+
+		actualCellState := cellState.GetNextState()
+
+		assert.True(t, cellState.IsEqual(actualCellState))
+	})
+
+	t.Run("should not give birth next to blinker without birth on three in a row", func(t *testing.T) {
+		nonTotalistic, _ := rule.New("B3-i/S23")
+		cellState, _ := cell.NewWithRule([][]bool{
+			{true, true, true},
+		}, nonTotalistic)
+		expectedString := "o"
+
+		actualCellState := cellState.GetNextState()
+
+		assert.Equal(t, expectedString, actualCellState.String())
+		rowOffset, colOffset := actualCellState.GetOffset()
+		assert.Equal(t, 0, rowOffset)
+		assert.Equal(t, 1, colOffset)
+	})
+
+	t.Run("should run glider as in life for rule with every letter", func(t *testing.T) {
+		allLetters, _ := rule.New("B3aceijknqry/S2aceikn3aceijknqry")
+		lifeState, _ := cell.New([][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		})
+		cellState, _ := cell.NewWithRule(lifeState.GetGeneration(), allLetters)
+
+		for i := 0; i < 8; i++ {
+			lifeState = lifeState.GetNextState()
+			cellState = cellState.GetNextState()
+		}
+
+		assert.Equal(t, lifeState.String(), cellState.String())
+		assert.Equal(t, fmt.Sprint(lifeState.GetOffset()), fmt.Sprint(cellState.GetOffset()))
+	})
+}
+
+func TestNeighborhoodRule(t *testing.T) {
+	testCases := []struct {
+		notation          string
+		expectedString    string
+		expectedRowOffset int
+		expectedColOffset int
+	}{
+		{"B1/S", "ooo\no-o\nooo", -1, -1},
+		{"B1/SV", "-o-\no-o\n-o-", -1, -1},
+		{"B1/SH", "oo-\no-o\n-oo", -1, -1},
+		{"B1/SW010101010", "-o-\no-o\n-o-", -1, -1},
+		{"B1/SW1000100000000000000010001", "o---o\n-----\n-----\n-----\no---o", -2, -2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("should give birth around single cell in "+testCase.notation, func(t *testing.T) {
+			cellRule, _ := rule.New(testCase.notation)
+			cellState, _ := cell.NewWithRule([][]bool{{true}}, cellRule)
+
+			actualCellState := cellState.GetNextState()
+
+			assert.Equal(t, testCase.expectedString, actualCellState.String())
+			rowOffset, colOffset := actualCellState.GetOffset()
+			assert.Equal(t, testCase.expectedRowOffset, rowOffset)
+			assert.Equal(t, testCase.expectedColOffset, colOffset)
+		})
+	}
+
+	t.Run("should step weighted moore rule as the moore rule", func(t *testing.T) {
+		weighted, _ := rule.New("B3/S23W111101111")
+		lifeState, _ := cell.New([][]bool{
+			{false, true, true},
+			{true, true, false},
+			{false, true, false},
+		})
+		cellState, _ := cell.NewWithRule(lifeState.GetGeneration(), weighted)
+
+		for i := 0; i < 20; i++ {
+			lifeState = lifeState.GetNextState()
+			cellState = cellState.GetNextState()
+		}
+
+		assert.Equal(t, lifeState.String(), cellState.String())
+		assert.Equal(t, fmt.Sprint(lifeState.GetOffset()), fmt.Sprint(cellState.GetOffset()))
+	})
+}
+
+func TestLargerThanLifeRule(t *testing.T) {
+	testCases := []struct {
+		notation      string
+		equalNotation string
+	}{
+		{"R1,C0,M0,S2..3,B3..3,NM", "B3/S23"},
+		{"R1,C0,M1,S3..4,B3..3,NM", "B3/S23"},
+		{"R1,C0,M0,S1..2,B1..1,NN", "B1/S12V"},
+		{"R1,C3,M0,S2..3,B3..3,NM", "B3/S23/C3"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("should step "+testCase.notation+" as "+testCase.equalNotation, func(t *testing.T) {
+			rangeRule, _ := rule.New(testCase.notation)
+			equalRule, _ := rule.New(testCase.equalNotation)
+			generation := [][]bool{
+				{false, true, true, false},
+				{true, true, false, true},
+				{false, true, false, false},
+			}
+			rangeState, _ := cell.NewWithRule(generation, rangeRule)
+			equalState, _ := cell.NewWithRule(generation, equalRule)
+
+			for i := 0; i < 30; i++ {
+				rangeState = rangeState.GetNextState()
+				equalState = equalState.GetNextState()
+			}
+
+			assert.Equal(t, equalState.String(), rangeState.String())
+			assert.Equal(t, fmt.Sprint(equalState.GetOffset()), fmt.Sprint(rangeState.GetOffset()))
+		})
+	}
+
+	t.Run("should give birth within the radius", func(t *testing.T) {
+		testCases := []struct {
+			notation          string
+			expectedString    string
+			expectedRowOffset int
+		}{
+			{"R2,C0,M0,S2..2,B1..1,NM", "ooooo\nooooo\noo-oo\nooooo\nooooo", -2},
+			{"R2,C0,M0,S2..2,B1..1,NN", "--o--\n-ooo-\noo-oo\n-ooo-\n--o--", -2},
+			{"R3,C0,M1,S1..1,B1..1,NN", "---o---\n--ooo--\n-ooooo-\nooooooo\n-ooooo-\n--ooo--\n---o---", -3},
+		}
+
+		for _, testCase := range testCases {
+			cellRule, _ := rule.New(testCase.notation)
+			cellState, _ := cell.NewWithRule([][]bool{{true}}, cellRule)
+
+			actualCellState := cellState.GetNextState()
+
+			assert.Equal(t, testCase.expectedString, actualCellState.String(), testCase.notation)
+			rowOffset, _ := actualCellState.GetOffset()
+			assert.Equal(t, testCase.expectedRowOffset, rowOffset, testCase.notation)
+		}
+	})
+}
+
+func TestTableRule(t *testing.T) {
+	t.Run("should move electron along wire", func(t *testing.T) {
+		wireWorld, _ := rule.New(rule.WireWorld)
+		cellState, _ := cell.NewWithStates([][]uint8{{2, 1, 3, 3, 3}}, wireWorld, 0, 0)
+		expectedStrings := []string{"32o33", "332o3", "3332o", "33332", "33333"}
+
+		for _, expectedString := range expectedStrings {
+			cellState = cellState.GetNextState()
+
+			assert.Equal(t, expectedString, cellState.String())
+		}
+	})
+
+	t.Run("should step life table as life", func(t *testing.T) {
+		table := "@TABLE\nn_states:2\nneighborhood:Moore\nsymmetries:permute\nvar a={0,1}\nvar b={a}\nvar c={a}\nvar d={a}\nvar e={a}\nvar f={a}\nvar g={a}\nvar h={a}\n" +
+			"0,1,1,1,0,0,0,0,0,1\n1,1,1,0,0,0,0,0,0,1\n1,1,1,1,0,0,0,0,0,1\n1,a,b,c,d,e,f,g,h,0\n"
+		tableRule, _ := rule.ParseTable(table)
+		lifeState, _ := cell.New([][]bool{{false, true, true}, {true, true, false}, {false, true, false}})
+		cellState, _ := cell.NewWithRule(lifeState.GetGeneration(), tableRule)
+
+		for i := 0; i < 30; i++ {
+			lifeState = lifeState.GetNextState()
+			cellState = cellState.GetNextState()
+		}
+
+		assert.Equal(t, lifeState.String(), cellState.String())
+		assert.Equal(t, fmt.Sprint(lifeState.GetOffset()), fmt.Sprint(cellState.GetOffset()))
+	})
+
+	t.Run("should step isotropic table as hensel rule", func(t *testing.T) {
+		table := "@TABLE\nn_states:2\nneighborhood:Moore\nsymmetries:rotate4reflect\n" +
+			"0,1,1,0,0,0,0,0,0,1\n0,1,0,0,0,0,0,1,1,1\n1,1,0,1,0,0,0,0,0,1\n" +
+			"1,{0,1},{0,1},{0,1},{0,1},{0,1},{0,1},{0,1},{0,1},0\n"
+		tableRule, _ := rule.ParseTable(table)
+		henselRule, _ := rule.New("B2a3a/S2e")
+		generation := [][]bool{
+			{true, true, false, true},
+			{false, true, true, true},
+			{true, false, false, true},
+		}
+		henselState, _ := cell.NewWithRule(generation, henselRule)
+		cellState, _ := cell.NewWithRule(generation, tableRule)
+
+		for i := 0; i < 20; i++ {
+			henselState = henselState.GetNextState()
+			cellState = cellState.GetNextState()
+		}
+
+		assert.Equal(t, henselState.String(), cellState.String())
+	})
+}
@@ -0,0 +1,231 @@
+package cell
+
+import (
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+// heatMap counts the generations each cell has been alive in, within the
+// box of every cell that has been alive since tracking started. The counts
+// are kept in a larger box, grown by half its size when a cell is alive
+// outside of it, and the rows are shared with the heat map of the state
+// before, a row being copied only when a cell in it is alive.
+type heatMap struct {
+	counts    [][]int
+	rowOffset int
+	colOffset int
+
+	// the box of the cells ever alive, the bottom and right exclusive
+	minRow int
+	minCol int
+	maxRow int
+	maxCol int
+}
+
+// WithAges returns the cell state tracking the age of every living cell,
+// the number of generations it has been alive in a row, carried on to
+// each next state. The living cells start at age 1.
+func (cellState *CellState) WithAges() *CellState {
+	trackedState := *cellState
+	trackedState.ages = makeAges(&trackedState, nil)
+	return &trackedState
+}
+
+// WithHeatMap returns the cell state counting, for every cell, the
+// generations it has been alive in from this one on, carried on to each
+// next state.
+func (cellState *CellState) WithHeatMap() *CellState {
+	trackedState := *cellState
+	trackedState.heat = addHeat(&heatMap{}, &trackedState)
+	return &trackedState
+}
+
+func (cellState *CellState) IsAgeTracked() bool {
+	return cellState.ages != nil
+}
+
+func (cellState *CellState) IsHeatMapTracked() bool {
+	return cellState.heat != nil
+}
+
+// GetAges returns the age of each cell in a grid of the same shape as
+// GetStates, 0 for the cells not alive, and nil when ages are not tracked.
+func (cellState *CellState) GetAges() [][]int {
+	if cellState.ages == nil {
+		return nil
+	}
+	return duplicateCounts(cellState.ages)
+}
+
+// GetAge returns the age of the cell at row and col, 0 when it is not
+// alive or ages are not tracked.
+func (cellState *CellState) GetAge(row, col int) int {
+	return getCount(cellState.ages, row-cellState.rowOffset, col-cellState.colOffset)
+}
+
+// GetHeatMap returns the number of generations each cell has been alive in
+// with the offset of its box, a cell ever alive counting at least 1, and
+// nil when the heat map is not tracked.
+func (cellState *CellState) GetHeatMap() ([][]int, int, int) {
+	heat := cellState.heat
+	if heat == nil {
+		return nil, 0, 0
+	}
+
+	counts := make([][]int, heat.maxRow-heat.minRow)
+	for i := range counts {
+		row := heat.counts[heat.minRow-heat.rowOffset+i]
+		counts[i] = make([]int, heat.maxCol-heat.minCol)
+		copy(counts[i], row[heat.minCol-heat.colOffset:])
+	}
+	return counts, heat.minRow, heat.minCol
+}
+
+// track carries the ages and the heat map of cellState on to nextState.
+func (cellState *CellState) track(nextState *CellState) {
+	if cellState.ages != nil {
+		nextState.ages = makeAges(nextState, cellState)
+	}
+	if cellState.heat != nil {
+		nextState.heat = addHeat(cellState.heat, nextState)
+	}
+}
+
+// makeAges returns the ages of the living cells of cellState, one more
+// than in previousState for the cells alive in both.
+func makeAges(cellState *CellState, previousState *CellState) [][]int {
+	generation := cellState.currentGeneration
+	ages := make([][]int, len(generation))
+	for i := 0; i < len(generation); i++ {
+		ages[i] = make([]int, len(generation[i]))
+		for j := 0; j < len(generation[i]); j++ {
+			if generation[i][j] != rule.Alive {
+				continue
+			}
+			ages[i][j] = 1
+			if previousState != nil {
+				ages[i][j] += previousState.GetAge(cellState.rowOffset+i, cellState.colOffset+j)
+			}
+		}
+	}
+
+	return ages
+}
+
+// addHeat returns heat with the living cells of cellState added, leaving
+// heat untouched.
+func addHeat(heat *heatMap, cellState *CellState) *heatMap {
+	generation := cellState.currentGeneration
+	if len(generation) == 0 {
+		return heat
+	}
+
+	nextHeat, isCopied := heat.cover(cellState.rowOffset, cellState.colOffset,
+		cellState.rowOffset+len(generation), cellState.colOffset+len(generation[0]))
+	for i := range generation {
+		row := cellState.rowOffset + i - nextHeat.rowOffset
+		for j := range generation[i] {
+			if generation[i][j] != rule.Alive {
+				continue
+			}
+			if !isCopied[row] {
+				copiedRow := make([]int, len(nextHeat.counts[row]))
+				copy(copiedRow, nextHeat.counts[row])
+				nextHeat.counts[row] = copiedRow
+				isCopied[row] = true
+			}
+			nextHeat.counts[row][cellState.colOffset+j-nextHeat.colOffset]++
+		}
+	}
+
+	return nextHeat
+}
+
+// cover returns a heat map with the counts of heat whose box takes in the
+// box from minRow and minCol to maxRow and maxCol, exclusive, and which of
+// its rows are its own rather than shared with heat.
+func (heat *heatMap) cover(minRow, minCol, maxRow, maxCol int) (*heatMap, []bool) {
+	var nextHeat = heatMap{
+		counts:    heat.counts,
+		rowOffset: heat.rowOffset,
+		colOffset: heat.colOffset,
+		minRow:    minRow,
+		minCol:    minCol,
+		maxRow:    maxRow,
+		maxCol:    maxCol,
+	}
+	if len(heat.counts) > 0 {
+		nextHeat.minRow, nextHeat.minCol = minInt(minRow, heat.minRow), minInt(minCol, heat.minCol)
+		nextHeat.maxRow, nextHeat.maxCol = maxInt(maxRow, heat.maxRow), maxInt(maxCol, heat.maxCol)
+	}
+
+	isInside := len(heat.counts) > 0 &&
+		minRow >= heat.rowOffset && maxRow <= heat.rowOffset+len(heat.counts) &&
+		minCol >= heat.colOffset && maxCol <= heat.colOffset+len(heat.counts[0])
+	if isInside {
+		nextHeat.counts = make([][]int, len(heat.counts))
+		copy(nextHeat.counts, heat.counts)
+		return &nextHeat, make([]bool, len(nextHeat.counts))
+	}
+
+	// the box grows by half its size on each side a cell is alive beyond
+	height, width := nextHeat.maxRow-nextHeat.minRow, nextHeat.maxCol-nextHeat.minCol
+	top, left, bottom, right := nextHeat.minRow, nextHeat.minCol, nextHeat.maxRow, nextHeat.maxCol
+	if len(heat.counts) > 0 {
+		top, left = heat.rowOffset, heat.colOffset
+		bottom, right = heat.rowOffset+len(heat.counts), heat.colOffset+len(heat.counts[0])
+		if minRow < top {
+			top = nextHeat.minRow - height/2
+		}
+		if minCol < left {
+			left = nextHeat.minCol - width/2
+		}
+		if maxRow > bottom {
+			bottom = nextHeat.maxRow + height/2
+		}
+		if maxCol > right {
+			right = nextHeat.maxCol + width/2
+		}
+	}
+
+	nextHeat.counts = make([][]int, bottom-top)
+	nextHeat.rowOffset, nextHeat.colOffset = top, left
+	isCopied := make([]bool, len(nextHeat.counts))
+	for i := range nextHeat.counts {
+		nextHeat.counts[i] = make([]int, right-left)
+		for j := range nextHeat.counts[i] {
+			nextHeat.counts[i][j] = getCount(heat.counts, top+i-heat.rowOffset, left+j-heat.colOffset)
+		}
+		isCopied[i] = true
+	}
+	return &nextHeat, isCopied
+}
+
+func getCount(counts [][]int, i, j int) int {
+	if i < 0 || i >= len(counts) || j < 0 || j >= len(counts[i]) {
+		return 0
+	}
+	return counts[i][j]
+}
+
+func duplicateCounts(counts [][]int) [][]int {
+	duplicatedCounts := make([][]int, len(counts))
+	for i := range counts {
+		duplicatedCounts[i] = make([]int, len(counts[i]))
+		copy(duplicatedCounts[i], counts[i])
+	}
+	return duplicatedCounts
+}
+
+func minInt(value, other int) int {
+	if value < other {
+		return value
+	}
+	return other
+}
+
+func maxInt(value, other int) int {
+	if value > other {
+		return value
+	}
+	return other
+}
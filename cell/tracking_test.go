@@ -0,0 +1,148 @@
+package cell_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAges(t *testing.T) {
+	t.Run("should return nil ages for untracked cell state", func(t *testing.T) {
+		blinker, _ := cell.New([][]bool{{true, true, true}})
+
+		assert.False(t, blinker.IsAgeTracked())
+		assert.Nil(t, blinker.GetAges())
+		assert.Equal(t, 0, blinker.GetAge(0, 0))
+	})
+
+	t.Run("should start living cells at age one", func(t *testing.T) {
+		blinker, _ := cell.New([][]bool{{true, true, true}})
+		var expectedAges = [][]int{{1, 1, 1}}
+
+		actualCellState := blinker.WithAges()
+
+		assert.True(t, actualCellState.IsAgeTracked())
+		assert.False(t, blinker.IsAgeTracked())
+		assert.Equal(t, expectedAges, actualCellState.GetAges())
+	})
+
+	t.Run("should add one to the age of cells staying alive", func(t *testing.T) {
+		blinker, _ := cell.New([][]bool{{true, true, true}})
+		var expectedAges = [][]int{{1}, {2}, {1}}
+
+		actualCellState := blinker.WithAges().GetNextState()
+
+		assert.Equal(t, expectedAges, actualCellState.GetAges())
+		assert.Equal(t, 2, actualCellState.GetAge(0, 1))
+		assert.Equal(t, 1, actualCellState.GetAge(-1, 1))
+		assert.Equal(t, 0, actualCellState.GetAge(0, 0))
+	})
+
+	t.Run("should keep ages of still life growing", func(t *testing.T) {
+		block, _ := cell.New([][]bool{{true, true}, {true, true}})
+		var expectedAges = [][]int{{4, 4}, {4, 4}}
+
+		actualCellState := block.WithAges().GetNextState().GetNextState().GetNextState()
+
+		assert.Equal(t, expectedAges, actualCellState.GetAges())
+	})
+
+	t.Run("should keep tracking ages after extinction", func(t *testing.T) {
+		single, _ := cell.New([][]bool{{true}})
+
+		actualCellState := single.WithAges().GetNextState().GetNextState()
+
+		assert.True(t, actualCellState.IsAgeTracked())
+		assert.Empty(t, actualCellState.GetAges())
+	})
+}
+
+func TestWithHeatMap(t *testing.T) {
+	t.Run("should return nil heat map for untracked cell state", func(t *testing.T) {
+		blinker, _ := cell.New([][]bool{{true, true, true}})
+
+		actualHeatMap, actualRowOffset, actualColOffset := blinker.GetHeatMap()
+
+		assert.False(t, blinker.IsHeatMapTracked())
+		assert.Nil(t, actualHeatMap)
+		assert.Equal(t, 0, actualRowOffset)
+		assert.Equal(t, 0, actualColOffset)
+	})
+
+	t.Run("should count generations each cell has been alive in", func(t *testing.T) {
+		blinker, _ := cell.New([][]bool{{true, true, true}})
+		var expectedHeatMap = [][]int{
+			{0, 1, 0},
+			{2, 3, 2},
+			{0, 1, 0},
+		}
+
+		actualCellState := blinker.WithHeatMap().GetNextState().GetNextState()
+
+		actualHeatMap, actualRowOffset, actualColOffset := actualCellState.GetHeatMap()
+		assert.True(t, actualCellState.IsHeatMapTracked())
+		assert.Equal(t, expectedHeatMap, actualHeatMap)
+		assert.Equal(t, -1, actualRowOffset)
+		assert.Equal(t, 0, actualColOffset)
+	})
+
+	t.Run("should grow heat map along a moving pattern", func(t *testing.T) {
+		glider, _ := cell.New([][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		})
+		actualCellState := glider.WithHeatMap()
+		for i := 0; i < 4; i++ {
+			actualCellState = actualCellState.GetNextState()
+		}
+
+		actualHeatMap, _, _ := actualCellState.GetHeatMap()
+
+		assert.Len(t, actualHeatMap, 4)
+		assert.Len(t, actualHeatMap[0], 4)
+	})
+
+	t.Run("should count every generation of a pattern moving far", func(t *testing.T) {
+		glider, _ := cell.New([][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		})
+		states := []*cell.CellState{glider.WithHeatMap()}
+		for i := 0; i < 60; i++ {
+			states = append(states, states[i].GetNextState())
+		}
+
+		actualHeatMap, actualRowOffset, actualColOffset := states[len(states)-1].GetHeatMap()
+
+		assert.Equal(t, 0, actualRowOffset)
+		assert.Equal(t, 0, actualColOffset)
+		assert.Len(t, actualHeatMap, 18)
+		assert.Len(t, actualHeatMap[0], 18)
+		for i := range actualHeatMap {
+			for j := range actualHeatMap[i] {
+				expectedCount := 0
+				for _, state := range states {
+					if state.IsAlive(i, j) {
+						expectedCount++
+					}
+				}
+				assert.Equal(t, expectedCount, actualHeatMap[i][j])
+			}
+		}
+	})
+
+	t.Run("should keep heat map of earlier cell state", func(t *testing.T) {
+		blinker, _ := cell.New([][]bool{{true, true, true}})
+		trackedState := blinker.WithHeatMap()
+		nextState := trackedState.GetNextState()
+		expectedHeatMap, _, _ := nextState.GetHeatMap()
+
+		nextState.GetNextState().GetNextState()
+		actualHeatMap, _, _ := nextState.GetHeatMap()
+
+		assert.Equal(t, expectedHeatMap, actualHeatMap)
+	})
+}
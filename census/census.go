@@ -0,0 +1,413 @@
+package census
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/irainia/gameoflife-go/apgcode"
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/io/random"
+	"github.com/irainia/gameoflife-go/object"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	ReportExtension = ".json"
+)
+
+const (
+	NilSoupStreamError        = "soup stream passed is nil"
+	NilRuleError              = "rule passed is nil"
+	MultiStateRuleError       = "rule with more than two states is not supported"
+	InvalidMaxGenerationError = "max generation is less than one (should be at least 1)"
+	InvalidMaxPeriodError     = "max period is less than one (should be at least 1)"
+	NegativeNumOfSoupsError   = "number of soups is negative"
+	PathEmptyError            = "report path passed is empty"
+	InvalidExtensionError     = "invalid report file extension (file should be *.json)"
+)
+
+var (
+	ErrNilSoupStream        = errors.New(NilSoupStreamError)
+	ErrNilRule              = errors.New(NilRuleError)
+	ErrMultiStateRule       = errors.New(MultiStateRuleError)
+	ErrInvalidMaxGeneration = errors.New(InvalidMaxGenerationError)
+	ErrInvalidMaxPeriod     = errors.New(InvalidMaxPeriodError)
+	ErrNegativeNumOfSoups   = errors.New(NegativeNumOfSoupsError)
+	ErrPathEmpty            = errors.New(PathEmptyError)
+	ErrInvalidExtension     = errors.New(InvalidExtensionError)
+)
+
+const (
+	periodRepeats        = 3
+	minStableGenerations = 50
+	reportPermission     = 0644
+
+	escapeCheckInterval = 64
+	escapeMargin        = 8
+)
+
+type Report struct {
+	Rule      string  `json:"rule"`
+	Symmetry  string  `json:"symmetry"`
+	Width     int     `json:"width"`
+	Height    int     `json:"height"`
+	Density   float64 `json:"density"`
+	FirstSeed int64   `json:"firstSeed"`
+
+	NumOfSoups        int `json:"numOfSoups"`
+	UnstabilisedSoups int `json:"unstabilisedSoups"`
+	NumOfObjects      int `json:"numOfObjects"`
+
+	StillLifes   map[string]int `json:"stillLifes"`
+	Oscillators  map[string]int `json:"oscillators"`
+	Spaceships   map[string]int `json:"spaceships"`
+	Unclassified int            `json:"unclassified"`
+}
+
+type Census struct {
+	soupStream    *random.SoupStream
+	rule          *rule.Rule
+	maxGeneration int
+	maxPeriod     int
+
+	report Report
+}
+
+func (report *Report) Write(path string) error {
+	if path == "" {
+		return ErrPathEmpty
+	}
+	if filepath.Ext(path) != ReportExtension {
+		return ErrInvalidExtension
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, os.FileMode(reportPermission))
+}
+
+func (census *Census) GetReport() Report {
+	report := census.report
+	report.StillLifes = copyTally(census.report.StillLifes)
+	report.Oscillators = copyTally(census.report.Oscillators)
+	report.Spaceships = copyTally(census.report.Spaceships)
+	return report
+}
+
+// Search runs numOfSoups more soups, each seeded one after the last soup
+// searched, and adds their objects to the census. It stops early when ctx
+// is done, keeping every soup completed so far.
+func (census *Census) Search(ctx context.Context, numOfSoups int) error {
+	if numOfSoups < 0 {
+		return ErrNegativeNumOfSoups
+	}
+
+	for i := 0; i < numOfSoups; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		soupStream, err := random.New(
+			census.soupStream.GetWidth(),
+			census.soupStream.GetHeight(),
+			census.soupStream.GetDensity(),
+			census.report.FirstSeed+int64(census.report.NumOfSoups),
+			census.soupStream.GetSymmetry(),
+		)
+		if err != nil {
+			return err
+		}
+		soup, err := soupStream.Read()
+		if err != nil {
+			return err
+		}
+		if err = census.AddSoup(soup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSoup runs soup until its population is periodic, then separates the
+// ash into objects and tallies their apgcodes.
+func (census *Census) AddSoup(soup [][]bool) error {
+	cellState, err := cell.NewWithRule(soup, census.rule)
+	if err != nil {
+		return err
+	}
+
+	census.report.NumOfSoups++
+	ash, escapees, isStable, err := census.stabilise(cellState)
+	if err != nil {
+		return err
+	}
+	if !isStable {
+		census.report.UnstabilisedSoups++
+		return nil
+	}
+
+	objects, err := object.Separate(ash)
+	if err != nil {
+		return err
+	}
+	for _, escapee := range escapees {
+		census.tally(escapee)
+	}
+	for _, ashObject := range objects {
+		code, err := apgcode.Encode(ashObject.GetCellState(), census.maxPeriod)
+		if err != nil {
+			census.report.NumOfObjects++
+			census.report.Unclassified++
+			continue
+		}
+		census.tally(code)
+	}
+
+	return nil
+}
+
+func (census *Census) tally(code string) {
+	census.report.NumOfObjects++
+	switch {
+	case strings.HasPrefix(code, apgcode.StillLifePrefix):
+		census.report.StillLifes[code]++
+	case strings.HasPrefix(code, apgcode.OscillatorPrefix):
+		census.report.Oscillators[code]++
+	case strings.HasPrefix(code, apgcode.SpaceshipPrefix):
+		census.report.Spaceships[code]++
+	}
+}
+
+func New(soupStream *random.SoupStream, cellRule *rule.Rule, maxGeneration, maxPeriod int) (*Census, error) {
+	if soupStream == nil {
+		return nil, ErrNilSoupStream
+	}
+	if cellRule == nil {
+		return nil, ErrNilRule
+	}
+	if cellRule.GetNumOfStates() > rule.MinNumOfStates {
+		return nil, ErrMultiStateRule
+	}
+	if maxGeneration < 1 {
+		return nil, ErrInvalidMaxGeneration
+	}
+	if maxPeriod < 1 {
+		return nil, ErrInvalidMaxPeriod
+	}
+
+	var census = Census{
+		soupStream:    soupStream,
+		rule:          cellRule,
+		maxGeneration: maxGeneration,
+		maxPeriod:     maxPeriod,
+		report: Report{
+			Rule:        cellRule.String(),
+			Symmetry:    soupStream.GetSymmetry(),
+			Width:       soupStream.GetWidth(),
+			Height:      soupStream.GetHeight(),
+			Density:     soupStream.GetDensity(),
+			FirstSeed:   soupStream.GetSeed(),
+			StillLifes:  make(map[string]int),
+			Oscillators: make(map[string]int),
+			Spaceships:  make(map[string]int),
+		},
+	}
+	return &census, nil
+}
+
+// stabilise runs cellState until its population is periodic. Spaceships
+// that have left the rest of the pattern behind are taken out on the way,
+// so they do not keep growing the universe, and returned as apgcodes.
+func (census *Census) stabilise(cellState *cell.CellState) (*cell.CellState, []string, bool, error) {
+	escapees := make([]string, 0)
+	populations := make([]int, 0)
+	for generation := 0; generation <= census.maxGeneration; generation++ {
+		if generation > 0 && generation%escapeCheckInterval == 0 {
+			remainingState, removed, err := census.removeEscapees(cellState)
+			if err != nil {
+				return nil, nil, false, err
+			}
+			if len(removed) > 0 {
+				cellState = remainingState
+				escapees = append(escapees, removed...)
+				populations = populations[:0]
+			}
+		}
+
+		populations = append(populations, cellState.GetPopulation())
+		if isPopulationPeriodic(populations, census.maxPeriod) {
+			return cellState, escapees, true, nil
+		}
+		cellState = cellState.GetNextState()
+	}
+
+	return cellState, escapees, false, nil
+}
+
+func (census *Census) removeEscapees(cellState *cell.CellState) (*cell.CellState, []string, error) {
+	objects, err := object.Separate(cellState)
+	if err != nil || len(objects) < 2 {
+		return cellState, nil, err
+	}
+
+	boxes := make([][4]int, len(objects))
+	for i, candidate := range objects {
+		boxes[i] = boundingBox(candidate.GetCellState())
+	}
+
+	escapees := make([]string, 0)
+	remaining := make([]*object.Object, 0, len(objects))
+	for i, candidate := range objects {
+		rest := [4]int{}
+		isRestEmpty := true
+		for j := range objects {
+			if j != i {
+				rest = unionBox(rest, boxes[j], isRestEmpty)
+				isRestEmpty = false
+			}
+		}
+
+		if isEscaping(candidate.GetCellState(), boxes[i], rest, census.maxPeriod) {
+			code, err := apgcode.Encode(candidate.GetCellState(), census.maxPeriod)
+			if err != nil {
+				return nil, nil, err
+			}
+			escapees = append(escapees, code)
+			continue
+		}
+		remaining = append(remaining, candidate)
+	}
+	if len(escapees) == 0 {
+		return cellState, nil, nil
+	}
+
+	remainingState, err := mergeObjects(remaining, cellState)
+	if err != nil {
+		return nil, nil, err
+	}
+	return remainingState, escapees, nil
+}
+
+// isEscaping tells whether the object in box is a spaceship clear of the
+// rest box by escapeMargin and flying away from it.
+func isEscaping(cellState *cell.CellState, box, rest [4]int, maxPeriod int) bool {
+	isAbove := box[2]+escapeMargin < rest[0]
+	isBelow := box[0]-escapeMargin > rest[2]
+	isLeft := box[3]+escapeMargin < rest[1]
+	isRight := box[1]-escapeMargin > rest[3]
+	if !isAbove && !isBelow && !isLeft && !isRight {
+		return false
+	}
+
+	_, rowShift, colShift, err := apgcode.Classify(cellState, maxPeriod)
+	if err != nil {
+		return false
+	}
+
+	return isAbove && rowShift < 0 || isBelow && rowShift > 0 ||
+		isLeft && colShift < 0 || isRight && colShift > 0
+}
+
+// boundingBox returns the top, left, bottom and right of the living cells.
+func boundingBox(cellState *cell.CellState) [4]int {
+	generation := cellState.GetGeneration()
+	rowOffset, colOffset := cellState.GetOffset()
+	return [4]int{rowOffset, colOffset, rowOffset + len(generation) - 1, colOffset + len(generation[0]) - 1}
+}
+
+func unionBox(box, other [4]int, isBoxEmpty bool) [4]int {
+	if isBoxEmpty {
+		return other
+	}
+	if other[0] < box[0] {
+		box[0] = other[0]
+	}
+	if other[1] < box[1] {
+		box[1] = other[1]
+	}
+	if other[2] > box[2] {
+		box[2] = other[2]
+	}
+	if other[3] > box[3] {
+		box[3] = other[3]
+	}
+	return box
+}
+
+func mergeObjects(objects []*object.Object, cellState *cell.CellState) (*cell.CellState, error) {
+	if len(objects) == 0 {
+		rowOffset, colOffset := cellState.GetOffset()
+		return cell.NewWithOffset([][]bool{{false}}, cellState.GetRule(), rowOffset, colOffset)
+	}
+
+	box := [4]int{}
+	for i, remaining := range objects {
+		box = unionBox(box, boundingBox(remaining.GetCellState()), i == 0)
+	}
+
+	generation := make([][]bool, box[2]-box[0]+1)
+	for i := 0; i < len(generation); i++ {
+		generation[i] = make([]bool, box[3]-box[1]+1)
+	}
+	for _, remaining := range objects {
+		objectGeneration := remaining.GetCellState().GetGeneration()
+		rowOffset, colOffset := remaining.GetOffset()
+		for i := 0; i < len(objectGeneration); i++ {
+			for j := 0; j < len(objectGeneration[i]); j++ {
+				if objectGeneration[i][j] {
+					generation[rowOffset-box[0]+i][colOffset-box[1]+j] = true
+				}
+			}
+		}
+	}
+
+	return cell.NewWithOffset(generation, cellState.GetRule(), box[0], box[1])
+}
+
+// isPopulationPeriodic tells whether the latest populations repeat with a
+// period of at most maxPeriod, over at least periodRepeats periods and
+// minStableGenerations generations.
+func isPopulationPeriodic(populations []int, maxPeriod int) bool {
+	last := len(populations) - 1
+	for period := 1; period <= maxPeriod; period++ {
+		window := period * periodRepeats
+		if window < minStableGenerations {
+			window = minStableGenerations
+		}
+		if window+period > len(populations) {
+			return false
+		}
+
+		isPeriodic := true
+		for i := last; i > last-window; i-- {
+			if populations[i] != populations[i-period] {
+				isPeriodic = false
+				break
+			}
+		}
+		if isPeriodic {
+			return true
+		}
+	}
+
+	return false
+}
+
+func copyTally(tally map[string]int) map[string]int {
+	copied := make(map[string]int, len(tally))
+	for code, count := range tally {
+		copied[code] = count
+	}
+
+	return copied
+}
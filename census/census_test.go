@@ -0,0 +1,192 @@
+package census_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/census"
+	"github.com/irainia/gameoflife-go/io/random"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSoupStream() *random.SoupStream {
+	soupStream, _ := random.New(16, 16, 0.5, 1, random.NoSymmetry)
+	return soupStream
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for nil soup stream", func(t *testing.T) {
+		var expectedError = census.NilSoupStreamError
+
+		actualCensus, actualError := census.New(nil, rule.Default(), 1000, 30)
+
+		assert.Nil(t, actualCensus)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for nil rule", func(t *testing.T) {
+		var expectedError = census.NilRuleError
+
+		actualCensus, actualError := census.New(newSoupStream(), nil, 1000, 30)
+
+		assert.Nil(t, actualCensus)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for multi-state rule", func(t *testing.T) {
+		briansBrain, _ := rule.New("/2/3")
+		var expectedError = census.MultiStateRuleError
+
+		actualCensus, actualError := census.New(newSoupStream(), briansBrain, 1000, 30)
+
+		assert.Nil(t, actualCensus)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid max generation", func(t *testing.T) {
+		var expectedError = census.InvalidMaxGenerationError
+
+		actualCensus, actualError := census.New(newSoupStream(), rule.Default(), 0, 30)
+
+		assert.Nil(t, actualCensus)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid max period", func(t *testing.T) {
+		var expectedError = census.InvalidMaxPeriodError
+
+		actualCensus, actualError := census.New(newSoupStream(), rule.Default(), 1000, 0)
+
+		assert.Nil(t, actualCensus)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return census with empty report of the soup settings", func(t *testing.T) {
+		actualCensus, actualError := census.New(newSoupStream(), rule.Default(), 1000, 30)
+
+		assert.Nil(t, actualError)
+		report := actualCensus.GetReport()
+		assert.Equal(t, rule.Conway, report.Rule)
+		assert.Equal(t, random.NoSymmetry, report.Symmetry)
+		assert.Equal(t, int64(1), report.FirstSeed)
+		assert.Equal(t, 0, report.NumOfSoups)
+		assert.Empty(t, report.StillLifes)
+	})
+}
+
+func TestAddSoup(t *testing.T) {
+	t.Run("should tally each object of the ash", func(t *testing.T) {
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 1000, 30)
+		soup := [][]bool{
+			{true, true, false, false, false, false, false},
+			{true, true, false, false, false, false, false},
+			{false, false, false, false, false, false, false},
+			{false, false, false, false, true, true, true},
+		}
+
+		actualError := soupCensus.AddSoup(soup)
+
+		assert.Nil(t, actualError)
+		report := soupCensus.GetReport()
+		assert.Equal(t, 1, report.NumOfSoups)
+		assert.Equal(t, 2, report.NumOfObjects)
+		assert.Equal(t, 1, report.StillLifes["xs4_33"])
+		assert.Equal(t, 1, report.Oscillators["xp2_7"])
+	})
+
+	t.Run("should tally escaping spaceship", func(t *testing.T) {
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 1000, 30)
+		soup := [][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		}
+
+		actualError := soupCensus.AddSoup(soup)
+
+		assert.Nil(t, actualError)
+		report := soupCensus.GetReport()
+		assert.Equal(t, 1, report.Spaceships["xq4_153"])
+		assert.Equal(t, 0, report.UnstabilisedSoups)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("should return error for negative number of soups", func(t *testing.T) {
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 1000, 30)
+		var expectedError = census.NegativeNumOfSoupsError
+
+		actualError := soupCensus.Search(context.Background(), -1)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should stop without soups for done context", func(t *testing.T) {
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 1000, 30)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		actualError := soupCensus.Search(ctx, 5)
+
+		assert.Equal(t, context.Canceled, actualError)
+		assert.Equal(t, 0, soupCensus.GetReport().NumOfSoups)
+	})
+
+	t.Run("should return same report for same seed", func(t *testing.T) {
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 5000, 30)
+		otherCensus, _ := census.New(newSoupStream(), rule.Default(), 5000, 30)
+
+		actualError := soupCensus.Search(context.Background(), 2)
+		otherError := otherCensus.Search(context.Background(), 2)
+
+		assert.Nil(t, actualError)
+		assert.Nil(t, otherError)
+		assert.Equal(t, 2, soupCensus.GetReport().NumOfSoups)
+		assert.Equal(t, otherCensus.GetReport(), soupCensus.GetReport())
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("should return error for empty path", func(t *testing.T) {
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 1000, 30)
+		report := soupCensus.GetReport()
+		var expectedError = census.PathEmptyError
+
+		actualError := report.Write("")
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for invalid extension", func(t *testing.T) {
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 1000, 30)
+		report := soupCensus.GetReport()
+		var expectedError = census.InvalidExtensionError
+
+		actualError := report.Write("report.txt")
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should write report as json", func(t *testing.T) {
+		dir, _ := ioutil.TempDir("", "census")
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "report.json")
+		soupCensus, _ := census.New(newSoupStream(), rule.Default(), 1000, 30)
+		_ = soupCensus.AddSoup([][]bool{{true, true}, {true, true}})
+		report := soupCensus.GetReport()
+
+		actualError := report.Write(path)
+		content, _ := ioutil.ReadFile(path)
+		var actualReport census.Report
+		unmarshalError := json.Unmarshal(content, &actualReport)
+
+		assert.Nil(t, actualError)
+		assert.Nil(t, unmarshalError)
+		assert.Equal(t, report, actualReport)
+	})
+}
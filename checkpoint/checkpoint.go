@@ -0,0 +1,219 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	FileName = "checkpoint.json"
+)
+
+const (
+	NilCellStateError            = "cell state passed is nil"
+	NegativeGenerationError      = "generation is negative"
+	GenerationBeyondTargetError  = "generation is beyond the number of generation"
+	EmptyDirectoryError          = "checkpoint directory is empty"
+	EmptyPathError               = "checkpoint path is empty"
+	NotFoundCheckpointError      = "checkpoint is not found"
+	InvalidCheckpointError       = "checkpoint is invalid"
+	UnsupportedVersionError      = "checkpoint version is not supported"
+	InvalidCheckpointFormatError = "checkpoint cells format is invalid ('-': dead, 'o': alive and '2'-'9', 'A'-'Z': other states)"
+)
+
+var (
+	ErrNilCellState            = errors.New(NilCellStateError)
+	ErrNegativeGeneration      = errors.New(NegativeGenerationError)
+	ErrGenerationBeyondTarget  = errors.New(GenerationBeyondTargetError)
+	ErrEmptyDirectory          = errors.New(EmptyDirectoryError)
+	ErrEmptyPath               = errors.New(EmptyPathError)
+	ErrNotFoundCheckpoint      = errors.New(NotFoundCheckpointError)
+	ErrInvalidCheckpoint       = errors.New(InvalidCheckpointError)
+	ErrUnsupportedVersion      = errors.New(UnsupportedVersionError)
+	ErrInvalidCheckpointFormat = errors.New(InvalidCheckpointFormatError)
+)
+
+const (
+	formatVersion = 1
+
+	filePermission      = 0644
+	directoryPermission = 0755
+	temporaryPattern    = FileName + ".*"
+)
+
+type Checkpoint struct {
+	generation      int
+	numOfGeneration int
+	cellState       *cell.CellState
+}
+
+type document struct {
+	Version         int      `json:"version"`
+	Generation      int      `json:"generation"`
+	NumOfGeneration int      `json:"numOfGeneration"`
+	Rule            string   `json:"rule"`
+	RowOffset       int      `json:"rowOffset"`
+	ColOffset       int      `json:"colOffset"`
+	Cells           []string `json:"cells"`
+}
+
+func (checkpoint *Checkpoint) GetGeneration() int {
+	return checkpoint.generation
+}
+
+func (checkpoint *Checkpoint) GetNumOfGeneration() int {
+	return checkpoint.numOfGeneration
+}
+
+func (checkpoint *Checkpoint) GetCellState() *cell.CellState {
+	return checkpoint.cellState
+}
+
+// Save writes the checkpoint into directory as FileName, replacing the
+// previous one atomically so an interrupted save never leaves a broken file.
+func (checkpoint *Checkpoint) Save(directory string) (string, error) {
+	if directory == "" {
+		return "", ErrEmptyDirectory
+	}
+	if err := os.MkdirAll(directory, directoryPermission); err != nil {
+		return "", err
+	}
+
+	rowOffset, colOffset := checkpoint.cellState.GetOffset()
+	content, err := json.MarshalIndent(document{
+		Version:         formatVersion,
+		Generation:      checkpoint.generation,
+		NumOfGeneration: checkpoint.numOfGeneration,
+		Rule:            checkpoint.cellState.GetRule().String(),
+		RowOffset:       rowOffset,
+		ColOffset:       colOffset,
+		Cells:           encodeStates(checkpoint.cellState.GetStates()),
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	temporaryFile, err := ioutil.TempFile(directory, temporaryPattern)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	if _, err = temporaryFile.Write(content); err != nil {
+		temporaryFile.Close()
+		return "", err
+	}
+	if err = temporaryFile.Close(); err != nil {
+		return "", err
+	}
+	if err = os.Chmod(temporaryFile.Name(), filePermission); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(directory, FileName)
+	if err = os.Rename(temporaryFile.Name(), path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+func New(generation, numOfGeneration int, cellState *cell.CellState) (*Checkpoint, error) {
+	if cellState == nil {
+		return nil, ErrNilCellState
+	}
+	if generation < 0 {
+		return nil, ErrNegativeGeneration
+	}
+	if generation > numOfGeneration {
+		return nil, ErrGenerationBeyondTarget
+	}
+
+	var checkpoint = Checkpoint{
+		generation:      generation,
+		numOfGeneration: numOfGeneration,
+		cellState:       cellState,
+	}
+	return &checkpoint, nil
+}
+
+func Load(path string) (*Checkpoint, error) {
+	if path == "" {
+		return nil, ErrEmptyPath
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, FileName)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, &io.FileError{Path: path, Err: ErrNotFoundCheckpoint, Cause: err}
+	}
+	if err != nil {
+		return nil, &io.FileError{Path: path, Err: io.ErrUnreadableFile, Cause: err}
+	}
+
+	var loaded document
+	if err = json.Unmarshal(content, &loaded); err != nil {
+		return nil, &io.FileError{Path: path, Err: ErrInvalidCheckpoint, Cause: err}
+	}
+	if loaded.Version != formatVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	cellRule, err := rule.Load(loaded.Rule)
+	if err != nil {
+		return nil, err
+	}
+	states, err := decodeStates(loaded.Cells)
+	if err != nil {
+		return nil, err
+	}
+	cellState, err := cell.NewWithStates(states, cellRule, loaded.RowOffset, loaded.ColOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(loaded.Generation, loaded.NumOfGeneration, cellState)
+}
+
+func encodeStates(states [][]uint8) []string {
+	rows := make([]string, len(states))
+	for i := 0; i < len(states); i++ {
+		row := make([]byte, len(states[i]))
+		for j := 0; j < len(states[i]); j++ {
+			row[j] = cell.StateCharacters[states[i][j]]
+		}
+		rows[i] = string(row)
+	}
+
+	return rows
+}
+
+func decodeStates(rows []string) ([][]uint8, error) {
+	if len(rows) == 0 {
+		// an extinct pattern is stored without rows
+		return [][]uint8{{0}}, nil
+	}
+
+	states := make([][]uint8, len(rows))
+	for i := 0; i < len(rows); i++ {
+		states[i] = make([]uint8, len(rows[i]))
+		for j := 0; j < len(rows[i]); j++ {
+			state, isValid := cell.ParseState(rows[i][j])
+			if !isValid {
+				return nil, ErrInvalidCheckpointFormat
+			}
+			states[i][j] = state
+		}
+	}
+
+	return states, nil
+}
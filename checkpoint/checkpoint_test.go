@@ -0,0 +1,247 @@
+package checkpoint_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/checkpoint"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	gliderGeneration = [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+)
+
+func makeDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return directory
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for nil cell state", func(t *testing.T) {
+		var expectedError = checkpoint.NilCellStateError
+
+		actualCheckpoint, actualError := checkpoint.New(0, 10, nil)
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for negative generation", func(t *testing.T) {
+		cellState, _ := cell.New(gliderGeneration)
+		var expectedError = checkpoint.NegativeGenerationError
+
+		actualCheckpoint, actualError := checkpoint.New(-1, 10, cellState)
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for generation beyond target", func(t *testing.T) {
+		cellState, _ := cell.New(gliderGeneration)
+		var expectedError = checkpoint.GenerationBeyondTargetError
+
+		actualCheckpoint, actualError := checkpoint.New(11, 10, cellState)
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+}
+
+func TestSave(t *testing.T) {
+	t.Run("should return error for empty directory", func(t *testing.T) {
+		cellState, _ := cell.New(gliderGeneration)
+		runCheckpoint, _ := checkpoint.New(0, 10, cellState)
+		var expectedError = checkpoint.EmptyDirectoryError
+
+		_, actualError := runCheckpoint.Save("")
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should write checkpoint file into directory", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		cellState, _ := cell.New(gliderGeneration)
+		runCheckpoint, _ := checkpoint.New(0, 10, cellState)
+		var expectedPath = filepath.Join(directory, checkpoint.FileName)
+
+		actualPath, actualError := runCheckpoint.Save(directory)
+		files, _ := ioutil.ReadDir(directory)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedPath, actualPath)
+		assert.Len(t, files, 1)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should return nil and error for empty path", func(t *testing.T) {
+		var expectedError = checkpoint.EmptyPathError
+
+		actualCheckpoint, actualError := checkpoint.Load("")
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for non existent checkpoint", func(t *testing.T) {
+		var expectedError = "./nonexistent.json: " + checkpoint.NotFoundCheckpointError + ": no such file or directory"
+
+		actualCheckpoint, actualError := checkpoint.Load("./nonexistent.json")
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid checkpoint", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, checkpoint.FileName)
+		ioutil.WriteFile(path, []byte("{"), os.ModePerm)
+		var expectedError = path + ": " + checkpoint.InvalidCheckpointError + ": unexpected end of JSON input"
+
+		actualCheckpoint, actualError := checkpoint.Load(path)
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error wrapping the cause of an unreadable checkpoint", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, checkpoint.FileName)
+		ioutil.WriteFile(path, []byte("{"), os.ModePerm)
+
+		_, invalidError := checkpoint.Load(path)
+		_, notFoundError := checkpoint.Load(filepath.Join(directory, "nonexistent.json"))
+
+		var syntaxError *json.SyntaxError
+		assert.True(t, errors.Is(invalidError, checkpoint.ErrInvalidCheckpoint))
+		assert.True(t, errors.As(invalidError, &syntaxError))
+		assert.True(t, errors.Is(notFoundError, checkpoint.ErrNotFoundCheckpoint))
+		assert.True(t, errors.Is(notFoundError, fs.ErrNotExist))
+	})
+
+	t.Run("should return nil and error for unsupported version", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, checkpoint.FileName)
+		ioutil.WriteFile(path, []byte(`{"version":2}`), os.ModePerm)
+		var expectedError = checkpoint.UnsupportedVersionError
+
+		actualCheckpoint, actualError := checkpoint.Load(path)
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid cells format", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, checkpoint.FileName)
+		ioutil.WriteFile(path, []byte(`{"version":1,"rule":"B3/S23","numOfGeneration":1,"cells":["ox"]}`), os.ModePerm)
+		var expectedError = checkpoint.InvalidCheckpointFormatError
+
+		actualCheckpoint, actualError := checkpoint.Load(path)
+
+		assert.Nil(t, actualCheckpoint)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should restore generation, offset and rule from directory", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		highLife, _ := rule.New("B36/S23")
+		cellState, _ := cell.NewWithOffset(gliderGeneration, highLife, -7, 12)
+		runCheckpoint, _ := checkpoint.New(42, 100, cellState)
+		runCheckpoint.Save(directory)
+
+		actualCheckpoint, actualError := checkpoint.Load(directory)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 42, actualCheckpoint.GetGeneration())
+		assert.Equal(t, 100, actualCheckpoint.GetNumOfGeneration())
+		assert.Equal(t, highLife.String(), actualCheckpoint.GetCellState().GetRule().String())
+		assert.True(t, cellState.IsEqual(actualCheckpoint.GetCellState()))
+	})
+
+	t.Run("should restore decaying states of generations rule", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		starWars, _ := rule.New("B2/S345/C4")
+		cellState, _ := cell.NewWithStates([][]uint8{{1, 2, 3}, {0, 1, 1}}, starWars, 5, -5)
+		runCheckpoint, _ := checkpoint.New(3, 10, cellState)
+		runCheckpoint.Save(directory)
+
+		actualCheckpoint, actualError := checkpoint.Load(directory)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, starWars.String(), actualCheckpoint.GetCellState().GetRule().String())
+		assert.True(t, cellState.IsEqual(actualCheckpoint.GetCellState()))
+	})
+
+	t.Run("should restore states of rule table", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		wireWorld, _ := rule.New(rule.WireWorld)
+		cellState, _ := cell.NewWithStates([][]uint8{{2, 1, 3, 3}}, wireWorld, 0, 0)
+		runCheckpoint, _ := checkpoint.New(1, 10, cellState)
+		runCheckpoint.Save(directory)
+
+		actualCheckpoint, actualError := checkpoint.Load(directory)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, rule.WireWorld, actualCheckpoint.GetCellState().GetRule().String())
+		assert.True(t, cellState.IsEqual(actualCheckpoint.GetCellState()))
+	})
+
+	t.Run("should restore extinct cell state with its offset", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		cellState, _ := cell.NewWithOffset([][]bool{{true}}, rule.Default(), 3, 4)
+		extinctState := cellState.GetNextState()
+		runCheckpoint, _ := checkpoint.New(1, 10, extinctState)
+		runCheckpoint.Save(directory)
+
+		actualCheckpoint, actualError := checkpoint.Load(directory)
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualCheckpoint.GetCellState().GetGeneration(), 0)
+		assert.True(t, extinctState.IsEqual(actualCheckpoint.GetCellState()))
+	})
+
+	t.Run("should continue with identical result after resume", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		cellState, _ := cell.New(gliderGeneration)
+		for i := 0; i < 10; i++ {
+			cellState = cellState.GetNextState()
+		}
+		runCheckpoint, _ := checkpoint.New(10, 30, cellState)
+		runCheckpoint.Save(directory)
+		resumedCheckpoint, _ := checkpoint.Load(directory)
+		resumedState := resumedCheckpoint.GetCellState()
+
+		for i := 10; i < 30; i++ {
+			cellState = cellState.GetNextState()
+			resumedState = resumedState.GetNextState()
+		}
+
+		assert.True(t, cellState.IsEqual(resumedState))
+	})
+}
@@ -0,0 +1,364 @@
+package diff
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"path/filepath"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/io/png"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	ImageExtension = png.FileExtension
+)
+
+const (
+	NilCellStateError     = "cell state passed is nil"
+	PathEmptyError        = "image path passed is empty"
+	InvalidExtensionError = "invalid image file extension (file should be *.png)"
+	EmptyDiffError        = "diff is empty, both generations are extinct"
+)
+
+var (
+	ErrNilCellState     = errors.New(NilCellStateError)
+	ErrPathEmpty        = errors.New(PathEmptyError)
+	ErrInvalidExtension = errors.New(InvalidExtensionError)
+	ErrEmptyDiff        = errors.New(EmptyDiffError)
+)
+
+// Kind is what happens to a cell from the first generation to the second.
+type Kind uint8
+
+const (
+	Empty Kind = iota
+	Unchanged
+	Born
+	Died
+	Changed
+)
+
+const (
+	// KindCharacters holds the character of each kind, so a kind is written
+	// as KindCharacters[kind].
+	KindCharacters = "-o+x*"
+
+	colorReset = "\x1b[0m"
+
+	// shiftWindow is how far from lining up the boxes of both generations
+	// a shift is looked for, as cells are born and die at their edges.
+	shiftWindow = 2
+)
+
+var (
+	kindColors = [...]string{
+		Empty:     "\x1b[90m",
+		Unchanged: "\x1b[37m",
+		Born:      "\x1b[32m",
+		Died:      "\x1b[31m",
+		Changed:   "\x1b[33m",
+	}
+	kindImageColors = [...]color.RGBA{
+		Empty:     {R: 0x20, G: 0x20, B: 0x20, A: 0xff},
+		Unchanged: {R: 0xc0, G: 0xc0, B: 0xc0, A: 0xff},
+		Born:      {R: 0x30, G: 0xc0, B: 0x40, A: 0xff},
+		Died:      {R: 0xe0, G: 0x30, B: 0x30, A: 0xff},
+		Changed:   {R: 0xf0, G: 0xb0, B: 0x20, A: 0xff},
+	}
+)
+
+// Diff is the cell by cell comparison of two generations, the second moved
+// back by its shift, within the box holding the living cells of both.
+type Diff struct {
+	kinds     [][]Kind
+	rowOffset int
+	colOffset int
+	rowShift  int
+	colShift  int
+
+	numOfUnchanged int
+	numOfBirths    int
+	numOfDeaths    int
+	numOfChanges   int
+}
+
+func (diff *Diff) GetKinds() [][]Kind {
+	kinds := make([][]Kind, len(diff.kinds))
+	for i := range diff.kinds {
+		kinds[i] = make([]Kind, len(diff.kinds[i]))
+		copy(kinds[i], diff.kinds[i])
+	}
+	return kinds
+}
+
+// GetKind returns the kind of the cell at row and col of the first
+// generation.
+func (diff *Diff) GetKind(row, col int) Kind {
+	i, j := row-diff.rowOffset, col-diff.colOffset
+	if i < 0 || i >= len(diff.kinds) || j < 0 || j >= len(diff.kinds[i]) {
+		return Empty
+	}
+	return diff.kinds[i][j]
+}
+
+func (diff *Diff) GetOffset() (int, int) {
+	return diff.rowOffset, diff.colOffset
+}
+
+// GetShift returns how far the second generation is moved from the first.
+func (diff *Diff) GetShift() (int, int) {
+	return diff.rowShift, diff.colShift
+}
+
+func (diff *Diff) GetNumOfUnchanged() int {
+	return diff.numOfUnchanged
+}
+
+func (diff *Diff) GetNumOfBirths() int {
+	return diff.numOfBirths
+}
+
+func (diff *Diff) GetNumOfDeaths() int {
+	return diff.numOfDeaths
+}
+
+// GetNumOfChanges returns the cells living in both generations in
+// different states.
+func (diff *Diff) GetNumOfChanges() int {
+	return diff.numOfChanges
+}
+
+// IsSame tells whether the generations are the same after the shift.
+func (diff *Diff) IsSame() bool {
+	return diff.numOfBirths == 0 && diff.numOfDeaths == 0 && diff.numOfChanges == 0
+}
+
+func (diff *Diff) String() string {
+	return diff.render(false)
+}
+
+// Colored returns String with each kind in its own terminal color.
+func (diff *Diff) Colored() string {
+	return diff.render(true)
+}
+
+func (diff *Diff) render(isColored bool) string {
+	var buffer bytes.Buffer
+	for i := 0; i < len(diff.kinds); i++ {
+		for j := 0; j < len(diff.kinds[i]); j++ {
+			kind := diff.kinds[i][j]
+			if isColored {
+				buffer.WriteString(kindColors[kind])
+			}
+			buffer.WriteByte(KindCharacters[kind])
+		}
+		if isColored {
+			buffer.WriteString(colorReset)
+		}
+
+		if i < len(diff.kinds)-1 {
+			buffer.WriteString("\n")
+		}
+	}
+
+	return buffer.String()
+}
+
+// WriteImage writes the diff to path as an image, each cell a square in
+// the color of its kind.
+func (diff *Diff) WriteImage(path string) error {
+	if path == "" {
+		return ErrPathEmpty
+	}
+	if filepath.Ext(path) != ImageExtension {
+		return ErrInvalidExtension
+	}
+	if len(diff.kinds) == 0 {
+		return ErrEmptyDiff
+	}
+
+	pngStream, err := png.New(path)
+	if err != nil {
+		return err
+	}
+	return pngStream.WriteCells(len(diff.kinds), len(diff.kinds[0]), func(i, j int) color.RGBA {
+		return kindImageColors[diff.kinds[i][j]]
+	})
+}
+
+// Compare returns the diff from before to after. When isTranslated, after
+// is first moved back by the shift that keeps the most cells unchanged,
+// the smallest such shift when there are many. The shifts looked at are
+// those near lining up the boxes of both generations, see findShift.
+func Compare(before, after *cell.CellState, isTranslated bool) (*Diff, error) {
+	if before == nil || after == nil {
+		return nil, ErrNilCellState
+	}
+
+	beforeCells, afterCells := collectCells(before), collectCells(after)
+	rowShift, colShift := 0, 0
+	if isTranslated {
+		rowShift, colShift = findShift(beforeCells, afterCells)
+	}
+
+	minRow, minCol, maxRow, maxCol := 0, 0, -1, -1
+	isFirst := true
+	include := func(row, col int) {
+		if isFirst || row < minRow {
+			minRow = row
+		}
+		if isFirst || row > maxRow {
+			maxRow = row
+		}
+		if isFirst || col < minCol {
+			minCol = col
+		}
+		if isFirst || col > maxCol {
+			maxCol = col
+		}
+		isFirst = false
+	}
+	for position := range beforeCells {
+		include(position[0], position[1])
+	}
+	for position := range afterCells {
+		include(position[0]-rowShift, position[1]-colShift)
+	}
+
+	var diff = Diff{
+		kinds:     make([][]Kind, maxRow-minRow+1),
+		rowOffset: minRow,
+		colOffset: minCol,
+		rowShift:  rowShift,
+		colShift:  colShift,
+	}
+	for i := range diff.kinds {
+		diff.kinds[i] = make([]Kind, maxCol-minCol+1)
+		for j := range diff.kinds[i] {
+			beforeState := before.GetState(minRow+i, minCol+j)
+			afterState := after.GetState(minRow+i+rowShift, minCol+j+colShift)
+			switch {
+			case beforeState == rule.Dead && afterState == rule.Dead:
+				diff.kinds[i][j] = Empty
+			case beforeState == rule.Dead:
+				diff.kinds[i][j] = Born
+				diff.numOfBirths++
+			case afterState == rule.Dead:
+				diff.kinds[i][j] = Died
+				diff.numOfDeaths++
+			case beforeState == afterState:
+				diff.kinds[i][j] = Unchanged
+				diff.numOfUnchanged++
+			default:
+				diff.kinds[i][j] = Changed
+				diff.numOfChanges++
+			}
+		}
+	}
+
+	return &diff, nil
+}
+
+// collectCells returns the state of each cell not dead by its absolute
+// position.
+func collectCells(cellState *cell.CellState) map[[2]int]uint8 {
+	cells := make(map[[2]int]uint8)
+	rowOffset, colOffset := cellState.GetOffset()
+	states := cellState.GetStates()
+	for i := range states {
+		for j := range states[i] {
+			if states[i][j] != rule.Dead {
+				cells[[2]int{rowOffset + i, colOffset + j}] = states[i][j]
+			}
+		}
+	}
+	return cells
+}
+
+// findShift tries the shifts lining up a corner of the box of before with
+// the same corner of the box of after, and those within shiftWindow of
+// them, counting for each how many cells of before it takes to a cell of
+// after in the same state.
+func findShift(beforeCells, afterCells map[[2]int]uint8) (int, int) {
+	if len(beforeCells) == 0 || len(afterCells) == 0 {
+		return 0, 0
+	}
+
+	beforeMin, beforeMax := findBounds(beforeCells)
+	afterMin, afterMax := findBounds(afterCells)
+	rowShifts := []int{afterMin[0] - beforeMin[0], afterMax[0] - beforeMax[0]}
+	colShifts := []int{afterMin[1] - beforeMin[1], afterMax[1] - beforeMax[1]}
+
+	best, bestMatches := [2]int{}, countMatches(beforeCells, afterCells, [2]int{})
+	isCounted := map[[2]int]bool{best: true}
+	for _, rowShift := range rowShifts {
+		for _, colShift := range colShifts {
+			for i := rowShift - shiftWindow; i <= rowShift+shiftWindow; i++ {
+				for j := colShift - shiftWindow; j <= colShift+shiftWindow; j++ {
+					shift := [2]int{i, j}
+					if isCounted[shift] {
+						continue
+					}
+					isCounted[shift] = true
+
+					numOfMatches := countMatches(beforeCells, afterCells, shift)
+					if numOfMatches > bestMatches || (numOfMatches == bestMatches && isShorter(shift, best)) {
+						best, bestMatches = shift, numOfMatches
+					}
+				}
+			}
+		}
+	}
+	return best[0], best[1]
+}
+
+// findBounds returns the top left and the bottom right corners of the box
+// holding cells.
+func findBounds(cells map[[2]int]uint8) ([2]int, [2]int) {
+	isFirst := true
+	var topLeft, bottomRight [2]int
+	for position := range cells {
+		for k := range position {
+			if isFirst || position[k] < topLeft[k] {
+				topLeft[k] = position[k]
+			}
+			if isFirst || position[k] > bottomRight[k] {
+				bottomRight[k] = position[k]
+			}
+		}
+		isFirst = false
+	}
+	return topLeft, bottomRight
+}
+
+func countMatches(beforeCells, afterCells map[[2]int]uint8, shift [2]int) int {
+	numOfMatches := 0
+	for position, state := range beforeCells {
+		if afterState, isFound := afterCells[[2]int{position[0] + shift[0], position[1] + shift[1]}]; isFound && afterState == state {
+			numOfMatches++
+		}
+	}
+	return numOfMatches
+}
+
+// isShorter orders shifts by their length, then by row and column so the
+// shift found does not depend on the order of a map.
+func isShorter(shift, other [2]int) bool {
+	length, otherLength := abs(shift[0])+abs(shift[1]), abs(other[0])+abs(other[1])
+	if length != otherLength {
+		return length < otherLength
+	}
+	if shift[0] != other[0] {
+		return shift[0] < other[0]
+	}
+	return shift[1] < other[1]
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,187 @@
+package diff_test
+
+import (
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/diff"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	gliderGeneration = [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+	blinkerGeneration = [][]bool{
+		{true, true, true},
+	}
+)
+
+func makeDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "diff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return directory
+}
+
+func TestCompare(t *testing.T) {
+	t.Run("should return nil and error for nil cell state", func(t *testing.T) {
+		glider, _ := cell.New(gliderGeneration)
+		var expectedError = diff.NilCellStateError
+
+		actualDiff, actualError := diff.Compare(nil, glider, false)
+		assert.Nil(t, actualDiff)
+		assert.EqualError(t, actualError, expectedError)
+
+		actualDiff, actualError = diff.Compare(glider, nil, false)
+		assert.Nil(t, actualDiff)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return births, deaths and unchanged cells in place", func(t *testing.T) {
+		blinker, _ := cell.NewWithOffset(blinkerGeneration, rule.Default(), 5, 5)
+		var expectedString = "-+-\nxox\n-+-"
+
+		actualDiff, actualError := diff.Compare(blinker, blinker.GetNextState(), false)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedString, actualDiff.String())
+		assert.Equal(t, 2, actualDiff.GetNumOfBirths())
+		assert.Equal(t, 2, actualDiff.GetNumOfDeaths())
+		assert.Equal(t, 1, actualDiff.GetNumOfUnchanged())
+		assert.Equal(t, 0, actualDiff.GetNumOfChanges())
+		assert.False(t, actualDiff.IsSame())
+		actualRowOffset, actualColOffset := actualDiff.GetOffset()
+		assert.Equal(t, 4, actualRowOffset)
+		assert.Equal(t, 5, actualColOffset)
+		assert.Equal(t, diff.Born, actualDiff.GetKind(4, 6))
+		assert.Equal(t, diff.Died, actualDiff.GetKind(5, 5))
+		assert.Equal(t, diff.Empty, actualDiff.GetKind(0, 0))
+		assert.Len(t, actualDiff.GetKinds(), 3)
+	})
+
+	t.Run("should return the same generations after the shift when translated", func(t *testing.T) {
+		glider, _ := cell.New(gliderGeneration)
+		movedGlider := glider.GetNextState().GetNextState().GetNextState().GetNextState()
+
+		inPlaceDiff, inPlaceError := diff.Compare(glider, movedGlider, false)
+		translatedDiff, translatedError := diff.Compare(glider, movedGlider, true)
+
+		assert.Nil(t, inPlaceError)
+		assert.False(t, inPlaceDiff.IsSame())
+		assert.Nil(t, translatedError)
+		assert.True(t, translatedDiff.IsSame())
+		assert.Equal(t, 5, translatedDiff.GetNumOfUnchanged())
+		actualRowShift, actualColShift := translatedDiff.GetShift()
+		assert.Equal(t, 1, actualRowShift)
+		assert.Equal(t, 1, actualColShift)
+	})
+
+	t.Run("should return shift of generations far apart", func(t *testing.T) {
+		glider, _ := cell.New(gliderGeneration)
+		movedGlider, _ := cell.NewWithOffset(gliderGeneration, rule.Default(), 5000, -3000)
+		nextGlider := glider.GetNextState()
+
+		actualDiff, actualError := diff.Compare(glider, movedGlider, true)
+		nextDiff, nextError := diff.Compare(glider, nextGlider, true)
+
+		assert.Nil(t, actualError)
+		assert.True(t, actualDiff.IsSame())
+		actualRowShift, actualColShift := actualDiff.GetShift()
+		assert.Equal(t, 5000, actualRowShift)
+		assert.Equal(t, -3000, actualColShift)
+		assert.Nil(t, nextError)
+		assert.Equal(t, 3, nextDiff.GetNumOfUnchanged())
+	})
+
+	t.Run("should return no shift for generations matching best in place", func(t *testing.T) {
+		blinker, _ := cell.New(blinkerGeneration)
+
+		actualDiff, actualError := diff.Compare(blinker, blinker.GetNextState(), true)
+
+		assert.Nil(t, actualError)
+		actualRowShift, actualColShift := actualDiff.GetShift()
+		assert.Equal(t, 0, actualRowShift)
+		assert.Equal(t, 0, actualColShift)
+		assert.Equal(t, 1, actualDiff.GetNumOfUnchanged())
+	})
+
+	t.Run("should return changes for cells living in other states", func(t *testing.T) {
+		starWars, _ := rule.New("B2/S345/C4")
+		before, _ := cell.NewWithStates([][]uint8{{1, 1, 2}}, starWars, 0, 0)
+		after, _ := cell.NewWithStates([][]uint8{{1, 3, 0}}, starWars, 0, 0)
+		var expectedString = "o*x"
+
+		actualDiff, actualError := diff.Compare(before, after, false)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedString, actualDiff.String())
+		assert.Equal(t, 1, actualDiff.GetNumOfChanges())
+	})
+
+	t.Run("should return births only from an extinct generation", func(t *testing.T) {
+		single, _ := cell.New([][]bool{{true}})
+		glider, _ := cell.New(gliderGeneration)
+
+		actualDiff, actualError := diff.Compare(single.GetNextState(), glider, true)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 5, actualDiff.GetNumOfBirths())
+		assert.Equal(t, "-+-\n--+\n+++", actualDiff.String())
+	})
+
+	t.Run("should return colored string with the same characters", func(t *testing.T) {
+		blinker, _ := cell.New(blinkerGeneration)
+		blinkerDiff, _ := diff.Compare(blinker, blinker.GetNextState(), false)
+
+		actualColored := blinkerDiff.Colored()
+
+		assert.Contains(t, actualColored, "\x1b[32m+")
+		assert.Contains(t, actualColored, "\x1b[31mx")
+		assert.Equal(t, 3, strings.Count(actualColored, "\x1b[0m"))
+	})
+}
+
+func TestWriteImage(t *testing.T) {
+	t.Run("should return error for invalid path or empty diff", func(t *testing.T) {
+		blinker, _ := cell.New(blinkerGeneration)
+		blinkerDiff, _ := diff.Compare(blinker, blinker.GetNextState(), false)
+		single, _ := cell.New([][]bool{{true}})
+		emptyDiff, _ := diff.Compare(single.GetNextState(), single.GetNextState(), false)
+
+		assert.EqualError(t, blinkerDiff.WriteImage(""), diff.PathEmptyError)
+		assert.EqualError(t, blinkerDiff.WriteImage("diff.jpg"), diff.InvalidExtensionError)
+		assert.EqualError(t, emptyDiff.WriteImage("diff.png"), diff.EmptyDiffError)
+	})
+
+	t.Run("should write image of eight pixels a cell", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "diff.png")
+		blinker, _ := cell.New(blinkerGeneration)
+		blinkerDiff, _ := diff.Compare(blinker, blinker.GetNextState(), false)
+
+		actualError := blinkerDiff.WriteImage(path)
+
+		assert.Nil(t, actualError)
+		imageFile, _ := os.Open(path)
+		defer imageFile.Close()
+		actualImage, decodeError := png.Decode(imageFile)
+		assert.Nil(t, decodeError)
+		assert.Equal(t, 24, actualImage.Bounds().Dx())
+		assert.Equal(t, 24, actualImage.Bounds().Dy())
+		red, green, _, _ := actualImage.At(12, 4).RGBA()
+		assert.True(t, green > red)
+		red, green, _, _ = actualImage.At(4, 12).RGBA()
+		assert.True(t, red > green)
+	})
+}
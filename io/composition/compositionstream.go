@@ -0,0 +1,275 @@
+package composition
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/file"
+	"github.com/irainia/gameoflife-go/io/rle"
+	"github.com/irainia/gameoflife-go/library"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/irainia/gameoflife-go/transform"
+)
+
+const (
+	FileExtension = ".json"
+)
+
+const (
+	PathEmptyError         = "path passed is empty"
+	InvalidExtensionError  = "invalid file extension (file should be *.json)"
+	NotFoundFileError      = "file is not found"
+	InvalidFormatError     = "composition is invalid (use: {\"rule\": [rule], \"patterns\": [{\"pattern\" or \"path\", \"row\", \"col\", \"transform\", \"phase\"}]})"
+	NoPatternsError        = "composition has no patterns"
+	InvalidSourceError     = "each pattern should have either a pattern name or a path"
+	InvalidSourcePathError = "pattern path should be *.cell or *.rle"
+	NegativePhaseError     = "phase is negative"
+	ExtinctPatternError    = "pattern dies out before its phase"
+	TooLargeError          = "composition should fit in 4096 by 4096 cells"
+)
+
+var (
+	ErrPathEmpty         = errors.New(PathEmptyError)
+	ErrInvalidExtension  = errors.New(InvalidExtensionError)
+	ErrNotFoundFile      = errors.New(NotFoundFileError)
+	ErrInvalidFormat     = errors.New(InvalidFormatError)
+	ErrNoPatterns        = errors.New(NoPatternsError)
+	ErrInvalidSource     = errors.New(InvalidSourceError)
+	ErrInvalidSourcePath = errors.New(InvalidSourcePathError)
+	ErrNegativePhase     = errors.New(NegativePhaseError)
+	ErrExtinctPattern    = errors.New(ExtinctPatternError)
+	ErrTooLarge          = errors.New(TooLargeError)
+)
+
+const (
+	maxExtent = 4096
+)
+
+// CompositionStream reads a generation put together from many patterns,
+// each from the library or a file, moved by its offset after being
+// transformed and run for the generations of its phase.
+type CompositionStream struct {
+	path string
+}
+
+type (
+	document struct {
+		Rule     string      `json:"rule"`
+		Patterns []placement `json:"patterns"`
+	}
+
+	placement struct {
+		Pattern   string `json:"pattern"`
+		Path      string `json:"path"`
+		Row       int    `json:"row"`
+		Col       int    `json:"col"`
+		Transform string `json:"transform"`
+		Phase     int    `json:"phase"`
+	}
+)
+
+func (compositionStream *CompositionStream) Read() ([][]bool, error) {
+	composed, err := compositionStream.readDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	cellRule := rule.Default()
+	if composed.Rule != "" {
+		cellRule, err = rule.Load(composed.Rule)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cells := make(map[[2]int]bool)
+	for _, placed := range composed.Patterns {
+		cellState, err := compositionStream.place(placed, cellRule)
+		if err != nil {
+			return nil, err
+		}
+
+		rowOffset, colOffset := cellState.GetOffset()
+		generation := cellState.GetGeneration()
+		for i := range generation {
+			for j := range generation[i] {
+				if generation[i][j] {
+					cells[[2]int{rowOffset + i, colOffset + j}] = true
+				}
+			}
+		}
+	}
+
+	return toGeneration(cells)
+}
+
+// GetRule returns the rule the phases are run with, empty for the default.
+func (compositionStream *CompositionStream) GetRule() (string, error) {
+	composed, err := compositionStream.readDocument()
+	if err != nil {
+		return "", err
+	}
+	return composed.Rule, nil
+}
+
+func (compositionStream *CompositionStream) readDocument() (*document, error) {
+	content, err := ioutil.ReadFile(compositionStream.path)
+	if os.IsNotExist(err) {
+		return nil, &io.FileError{Path: compositionStream.path, Err: ErrNotFoundFile, Cause: err}
+	}
+	if err != nil {
+		return nil, &io.FileError{Path: compositionStream.path, Err: io.ErrUnreadableFile, Cause: err}
+	}
+
+	var composed document
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&composed); err != nil {
+		return nil, &io.FileError{Path: compositionStream.path, Err: ErrInvalidFormat, Cause: err}
+	}
+	if len(composed.Patterns) == 0 {
+		return nil, ErrNoPatterns
+	}
+
+	return &composed, nil
+}
+
+// place returns the cell state of placed at its offset and phase.
+func (compositionStream *CompositionStream) place(placed placement, cellRule *rule.Rule) (*cell.CellState, error) {
+	if (placed.Pattern == "") == (placed.Path == "") {
+		return nil, ErrInvalidSource
+	}
+	if placed.Phase < 0 {
+		return nil, ErrNegativePhase
+	}
+
+	var generation [][]bool
+	var err error
+	if placed.Pattern != "" {
+		generation, err = readPattern(placed.Pattern)
+	} else {
+		generation, err = readFile(compositionStream.resolve(placed.Path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	placedTransform, err := transform.Get(valueOrDefault(placed.Transform, transform.Identity))
+	if err != nil {
+		return nil, err
+	}
+	cellState, err := cell.NewWithOffset(placedTransform.Apply(generation), cellRule, placed.Row, placed.Col)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < placed.Phase; i++ {
+		cellState = cellState.GetNextState()
+	}
+	if len(cellState.GetGeneration()) == 0 {
+		return nil, ErrExtinctPattern
+	}
+
+	return cellState, nil
+}
+
+// resolve returns path relative to the directory of the composition file.
+func (compositionStream *CompositionStream) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(compositionStream.path), path)
+}
+
+func readPattern(name string) ([][]bool, error) {
+	pattern, err := library.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	rleStream, err := pattern.GetReader()
+	if err != nil {
+		return nil, err
+	}
+	return rleStream.Read()
+}
+
+func readFile(path string) ([][]bool, error) {
+	switch filepath.Ext(path) {
+	case rle.FileExtension:
+		rleStream, err := rle.New(path)
+		if err != nil {
+			return nil, err
+		}
+		return rleStream.Read()
+	case file.FileExtension:
+		fileStream, err := file.New(path)
+		if err != nil {
+			return nil, err
+		}
+		return fileStream.Read()
+	default:
+		return nil, ErrInvalidSourcePath
+	}
+}
+
+// toGeneration returns the box holding every cell.
+func toGeneration(cells map[[2]int]bool) ([][]bool, error) {
+	minRow, minCol, maxRow, maxCol := 0, 0, 0, 0
+	isFirst := true
+	for position := range cells {
+		if isFirst || position[0] < minRow {
+			minRow = position[0]
+		}
+		if isFirst || position[0] > maxRow {
+			maxRow = position[0]
+		}
+		if isFirst || position[1] < minCol {
+			minCol = position[1]
+		}
+		if isFirst || position[1] > maxCol {
+			maxCol = position[1]
+		}
+		isFirst = false
+	}
+	// the differences are taken as unsigned so that coordinates far apart
+	// cannot overflow into a small box
+	if uint64(maxRow-minRow) >= maxExtent || uint64(maxCol-minCol) >= maxExtent {
+		return nil, ErrTooLarge
+	}
+
+	generation := make([][]bool, maxRow-minRow+1)
+	for i := range generation {
+		generation[i] = make([]bool, maxCol-minCol+1)
+	}
+	for position := range cells {
+		generation[position[0]-minRow][position[1]-minCol] = true
+	}
+
+	return generation, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func New(path string) (*CompositionStream, error) {
+	if path == "" {
+		return nil, ErrPathEmpty
+	}
+	if filepath.Ext(path) != FileExtension {
+		return nil, ErrInvalidExtension
+	}
+
+	var compositionStream = CompositionStream{
+		path: path,
+	}
+	return &compositionStream, nil
+}
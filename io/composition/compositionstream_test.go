@@ -0,0 +1,228 @@
+package composition_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/composition"
+	"github.com/irainia/gameoflife-go/library"
+	"github.com/irainia/gameoflife-go/transform"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeDirectory(t *testing.T, files map[string]string) string {
+	directory, err := ioutil.TempDir("", "composition")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err = ioutil.WriteFile(filepath.Join(directory, name), []byte(content), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return directory
+}
+
+func readComposition(t *testing.T, files map[string]string) ([][]bool, error) {
+	directory := makeDirectory(t, files)
+	defer os.RemoveAll(directory)
+
+	compositionStream, err := composition.New(filepath.Join(directory, "composition.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return compositionStream.Read()
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for empty path", func(t *testing.T) {
+		actualStream, actualError := composition.New("")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, composition.PathEmptyError)
+	})
+
+	t.Run("should return nil and error for invalid extension", func(t *testing.T) {
+		actualStream, actualError := composition.New("./composition.cell")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, composition.InvalidExtensionError)
+	})
+
+	t.Run("should return composition stream and nil for valid extension", func(t *testing.T) {
+		actualStream, actualError := composition.New("./composition.json")
+
+		assert.NotNil(t, actualStream)
+		assert.Nil(t, actualError)
+	})
+}
+
+func TestRead(t *testing.T) {
+	t.Run("should return nil and error for non existent file", func(t *testing.T) {
+		compositionStream, _ := composition.New("./non-existent.json")
+
+		actualGeneration, actualError := compositionStream.Read()
+
+		assert.Nil(t, actualGeneration)
+		assert.EqualError(t, actualError, "./non-existent.json: "+composition.NotFoundFileError+": no such file or directory")
+	})
+
+	t.Run("should return nil and error for invalid format", func(t *testing.T) {
+		var invalidCompositions = []string{
+			`{"patterns": [`,
+			`{"patterns": [{"pattern": "glider", "offset": 1}]}`,
+		}
+
+		for _, invalidComposition := range invalidCompositions {
+			actualGeneration, actualError := readComposition(t, map[string]string{"composition.json": invalidComposition})
+
+			assert.Nil(t, actualGeneration)
+			assert.True(t, errors.Is(actualError, composition.ErrInvalidFormat))
+		}
+	})
+
+	t.Run("should return error wrapping the cause of an invalid format", func(t *testing.T) {
+		_, actualError := readComposition(t, map[string]string{"composition.json": `{"patterns": [}`})
+
+		var fileError *io.FileError
+		var syntaxError *json.SyntaxError
+		assert.True(t, errors.Is(actualError, composition.ErrInvalidFormat))
+		assert.True(t, errors.As(actualError, &syntaxError))
+		if assert.True(t, errors.As(actualError, &fileError)) {
+			assert.Equal(t, "composition.json", filepath.Base(fileError.Path))
+		}
+	})
+
+	t.Run("should return nil and error for no patterns", func(t *testing.T) {
+		actualGeneration, actualError := readComposition(t, map[string]string{"composition.json": `{"patterns": []}`})
+
+		assert.Nil(t, actualGeneration)
+		assert.EqualError(t, actualError, composition.NoPatternsError)
+	})
+
+	t.Run("should return nil and error for pattern without or with both sources", func(t *testing.T) {
+		var invalidCompositions = []string{
+			`{"patterns": [{"row": 1}]}`,
+			`{"patterns": [{"pattern": "glider", "path": "glider.cell"}]}`,
+		}
+
+		for _, invalidComposition := range invalidCompositions {
+			actualGeneration, actualError := readComposition(t, map[string]string{"composition.json": invalidComposition})
+
+			assert.Nil(t, actualGeneration)
+			assert.EqualError(t, actualError, composition.InvalidSourceError)
+		}
+	})
+
+	t.Run("should return nil and error for invalid pattern of a placement", func(t *testing.T) {
+		var invalidCompositions = map[string]string{
+			`{"patterns": [{"pattern": "unknown"}]}`:                   library.UnknownPatternError,
+			`{"patterns": [{"path": "nested.json"}]}`:                  composition.InvalidSourcePathError,
+			`{"patterns": [{"pattern": "glider", "phase": -1}]}`:       composition.NegativePhaseError,
+			`{"patterns": [{"pattern": "glider", "transform": "up"}]}`: transform.UnknownTransformError,
+			`{"patterns": [{"pattern": "diehard", "phase": 130}]}`:     composition.ExtinctPatternError,
+		}
+
+		for invalidComposition, expectedError := range invalidCompositions {
+			actualGeneration, actualError := readComposition(t, map[string]string{"composition.json": invalidComposition})
+
+			assert.Nil(t, actualGeneration)
+			assert.EqualError(t, actualError, expectedError)
+		}
+	})
+
+	t.Run("should return nil and error for patterns placed too far apart", func(t *testing.T) {
+		var tooLargeCompositions = []string{
+			`{"patterns": [{"pattern": "block"}, {"pattern": "block", "row": 4095}]}`,
+			`{"patterns": [{"pattern": "block"}, {"pattern": "block", "col": -9000000000000000000}]}`,
+		}
+
+		for _, tooLargeComposition := range tooLargeCompositions {
+			actualGeneration, actualError := readComposition(t, map[string]string{"composition.json": tooLargeComposition})
+
+			assert.Nil(t, actualGeneration)
+			assert.EqualError(t, actualError, composition.TooLargeError)
+		}
+	})
+
+	t.Run("should place patterns at their offsets", func(t *testing.T) {
+		var files = map[string]string{
+			"composition.json": `{
+				"patterns": [
+					{"pattern": "block"},
+					{"path": "blinker.cell", "row": 3, "col": 4},
+					{"path": "./blinker.rle", "row": -2, "col": 1, "transform": "rot90"}
+				]
+			}`,
+			"blinker.cell": "ooo",
+			"blinker.rle":  "x = 3, y = 1\n3o!\n",
+		}
+		var expectedGeneration = [][]bool{
+			{false, true, false, false, false, false, false},
+			{false, true, false, false, false, false, false},
+			{true, true, false, false, false, false, false},
+			{true, true, false, false, false, false, false},
+			{false, false, false, false, false, false, false},
+			{false, false, false, false, true, true, true},
+		}
+
+		actualGeneration, actualError := readComposition(t, files)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedGeneration, actualGeneration)
+	})
+
+	t.Run("should run each pattern for its phase in place", func(t *testing.T) {
+		var files = map[string]string{
+			"composition.json": `{"patterns": [{"pattern": "glider", "phase": 4}, {"pattern": "block", "col": 5}]}`,
+		}
+		var expectedGeneration = [][]bool{
+			{false, false, false, false, true, true},
+			{false, true, false, false, true, true},
+			{false, false, true, false, false, false},
+			{true, true, true, false, false, false},
+		}
+
+		actualGeneration, actualError := readComposition(t, files)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedGeneration, actualGeneration)
+	})
+
+	t.Run("should run phases with the rule of the composition", func(t *testing.T) {
+		var files = map[string]string{
+			"composition.json": `{"rule": "B3/S", "patterns": [{"path": "blinker.cell", "phase": 1}]}`,
+			"blinker.cell":     "ooo",
+		}
+		var expectedGeneration = [][]bool{
+			{true},
+			{false},
+			{true},
+		}
+
+		actualGeneration, actualError := readComposition(t, files)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedGeneration, actualGeneration)
+	})
+}
+
+func TestGetRule(t *testing.T) {
+	t.Run("should return the rule of the composition", func(t *testing.T) {
+		directory := makeDirectory(t, map[string]string{
+			"composition.json": `{"rule": "B36/S23", "patterns": [{"pattern": "glider"}]}`,
+		})
+		defer os.RemoveAll(directory)
+		compositionStream, _ := composition.New(filepath.Join(directory, "composition.json"))
+
+		actualRule, actualError := compositionStream.GetRule()
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, "B36/S23", actualRule)
+	})
+}
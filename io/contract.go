@@ -8,4 +8,32 @@ type (
 	Writer interface {
 		Write(generation [][]bool) error
 	}
+
+	// StateReader is a Reader that can also read the cell states of rules
+	// with more than two states.
+	StateReader interface {
+		Reader
+		ReadStates() ([][]uint8, error)
+	}
+
+	// StateWriter is a Writer that can also write the cell states of rules
+	// with more than two states.
+	StateWriter interface {
+		Writer
+		WriteStates(states [][]uint8) error
+	}
+
+	// Validator is a Reader that can also read a whole source for every
+	// problem in it, not only the first, as read with a rule of numOfStates.
+	Validator interface {
+		Reader
+		Validate(numOfStates int) []error
+	}
+
+	// HeatWriter is a Writer that can also write a value for each cell,
+	// e.g. the ages or the heat map tracked by a cell state.
+	HeatWriter interface {
+		Writer
+		WriteHeat(heat [][]int) error
+	}
 )
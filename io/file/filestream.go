@@ -7,6 +7,10 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/rule"
 )
 
 const (
@@ -19,48 +23,166 @@ const (
 	NotFoundFileError     = "file is not found"
 	EmptyFileError        = "file is empty"
 	InvalidFormatError    = "format is invalid ('o': true and '-': false)"
+	InvalidStateError     = "state is invalid ('-': dead, 'o': alive and '2'-'9', 'A'-'Z': other states)"
+	EmptyRowError         = "row is empty (no empty line is allowed)"
+	UnevenRowError        = "row is not as long as the first row (the shape should be a rectangle)"
 	NilGenerationError    = "generation is nil"
 	EmptyGenerationError  = "generation is empty"
 )
 
+var (
+	ErrPathEmpty        = errors.New(PathEmptyError)
+	ErrInvalidExtension = errors.New(InvalidExtensionError)
+	ErrNotFoundFile     = errors.New(NotFoundFileError)
+	ErrEmptyFile        = errors.New(EmptyFileError)
+	ErrInvalidFormat    = errors.New(InvalidFormatError)
+	ErrInvalidState     = errors.New(InvalidStateError)
+	ErrEmptyRow         = errors.New(EmptyRowError)
+	ErrUnevenRow        = errors.New(UnevenRowError)
+	ErrNilGeneration    = errors.New(NilGenerationError)
+	ErrEmptyGeneration  = errors.New(EmptyGenerationError)
+)
+
+const (
+	carriageReturn = "\r"
+)
+
 type FileStream struct {
 	path string
 }
 
 func (fileStream *FileStream) Read() ([][]bool, error) {
-	if _, err := os.Stat(fileStream.path); os.IsNotExist(err) {
-		return nil, errors.New(NotFoundFileError)
+	states, errs := fileStream.parse(cell.StateCharacters[:rule.MinNumOfStates], ErrInvalidFormat)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	outputGeneration := make([][]bool, len(states))
+	for i := 0; i < len(states); i++ {
+		outputGeneration[i] = make([]bool, len(states[i]))
+		for j := 0; j < len(states[i]); j++ {
+			outputGeneration[i][j] = states[i][j] == rule.Alive
+		}
 	}
 
-	readGeneration, _ := ioutil.ReadFile(fileStream.path)
-	if string(readGeneration) == "" {
-		return nil, errors.New(EmptyFileError)
+	return outputGeneration, nil
+}
+
+// ReadStates reads a file that may hold other states than alive and dead,
+// each written as its character in cell.StateCharacters.
+func (fileStream *FileStream) ReadStates() ([][]uint8, error) {
+	states, errs := fileStream.parse(cell.StateCharacters, ErrInvalidState)
+	if len(errs) > 0 {
+		return nil, errs[0]
 	}
 
-	splitGeneration := strings.Split(string(readGeneration), "\n")
-	outputGeneration := make([][]bool, len(splitGeneration))
-	for i := 0; i < len(splitGeneration); i++ {
-		outputGeneration[i] = make([]bool, len(splitGeneration[i]))
-		for j := 0; j < len(splitGeneration[i]); j++ {
-			if splitGeneration[i][j] == 'o' {
-				outputGeneration[i][j] = true
-			} else if splitGeneration[i][j] == '-' {
-				outputGeneration[i][j] = false
-			} else {
-				return nil, errors.New(InvalidFormatError)
+	return states, nil
+}
+
+// Validate returns every problem of the file read with a rule of
+// numOfStates, nil when there is none.
+func (fileStream *FileStream) Validate(numOfStates int) []error {
+	if numOfStates <= rule.MinNumOfStates {
+		_, errs := fileStream.parse(cell.StateCharacters[:rule.MinNumOfStates], ErrInvalidFormat)
+		return errs
+	}
+	if numOfStates > len(cell.StateCharacters) {
+		numOfStates = len(cell.StateCharacters)
+	}
+
+	_, errs := fileStream.parse(cell.StateCharacters[:numOfStates], ErrInvalidState)
+	return errs
+}
+
+// parse returns the state of each cell, the index of its character in
+// characters, or every problem found in the file.
+func (fileStream *FileStream) parse(characters string, invalidCharacterError error) ([][]uint8, []error) {
+	rows, err := fileStream.readRows()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > 0 {
+			width = len(row)
+			break
+		}
+	}
+
+	errs := make([]error, 0)
+	report := func(line, column int, found byte, expected string, problem error) {
+		errs = append(errs, &io.ParseError{
+			Path:     fileStream.path,
+			Line:     line,
+			Column:   column,
+			Found:    found,
+			Expected: expected,
+			Err:      problem,
+		})
+	}
+
+	outputStates := make([][]uint8, len(rows))
+	for i, row := range rows {
+		if len(row) == 0 {
+			report(i+1, 1, io.EndOfLine, characters, ErrEmptyRow)
+			continue
+		}
+
+		outputStates[i] = make([]uint8, len(row))
+		for j := 0; j < len(row); j++ {
+			state := strings.IndexByte(characters, row[j])
+			if state < 0 {
+				report(i+1, j+1, row[j], characters, invalidCharacterError)
+				continue
 			}
+			outputStates[i][j] = uint8(state)
+		}
+
+		if len(row) < width {
+			report(i+1, len(row)+1, io.EndOfLine, characters, ErrUnevenRow)
+		} else if len(row) > width {
+			report(i+1, width+1, row[width], string(io.EndOfLine), ErrUnevenRow)
 		}
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
-	return outputGeneration, nil
+	return outputStates, nil
+}
+
+// readRows returns the lines of the file, each without the carriage return
+// of a CRLF line ending and without the empty lines the file ends with.
+func (fileStream *FileStream) readRows() ([]string, error) {
+	readGeneration, err := ioutil.ReadFile(fileStream.path)
+	if os.IsNotExist(err) {
+		return nil, &io.FileError{Path: fileStream.path, Err: ErrNotFoundFile, Cause: err}
+	}
+	if err != nil {
+		return nil, &io.FileError{Path: fileStream.path, Err: io.ErrUnreadableFile, Cause: err}
+	}
+
+	rows := strings.Split(string(readGeneration), "\n")
+	for i := range rows {
+		rows[i] = strings.TrimSuffix(rows[i], carriageReturn)
+	}
+	for len(rows) > 0 && rows[len(rows)-1] == "" {
+		rows = rows[:len(rows)-1]
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	return rows, nil
 }
 
 func (fileStream *FileStream) Write(generation [][]bool) error {
 	if generation == nil {
-		return errors.New(NilGenerationError)
+		return ErrNilGeneration
 	}
 	if len(generation) == 0 {
-		return errors.New(EmptyGenerationError)
+		return ErrEmptyGeneration
 	}
 
 	var buffer bytes.Buffer
@@ -81,12 +203,37 @@ func (fileStream *FileStream) Write(generation [][]bool) error {
 	return ioutil.WriteFile(fileStream.path, buffer.Bytes(), os.ModePerm)
 }
 
+func (fileStream *FileStream) WriteStates(states [][]uint8) error {
+	if states == nil {
+		return ErrNilGeneration
+	}
+	if len(states) == 0 {
+		return ErrEmptyGeneration
+	}
+
+	var buffer bytes.Buffer
+	for i := 0; i < len(states); i++ {
+		for j := 0; j < len(states[i]); j++ {
+			if int(states[i][j]) >= len(cell.StateCharacters) {
+				return ErrInvalidState
+			}
+			buffer.WriteByte(cell.StateCharacters[states[i][j]])
+		}
+
+		if i < len(states)-1 {
+			buffer.WriteString("\n")
+		}
+	}
+
+	return ioutil.WriteFile(fileStream.path, buffer.Bytes(), os.ModePerm)
+}
+
 func New(path string) (*FileStream, error) {
 	if path == "" {
-		return nil, errors.New(PathEmptyError)
+		return nil, ErrPathEmpty
 	}
 	if !isExtensionValid(path) {
-		return nil, errors.New(InvalidExtensionError)
+		return nil, ErrInvalidExtension
 	}
 
 	var fileStream = FileStream{
@@ -1,12 +1,16 @@
 package file_test
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/io"
 	"github.com/irainia/gameoflife-go/io/file"
 	"github.com/stretchr/testify/assert"
 )
@@ -126,7 +130,7 @@ func TestRead(t *testing.T) {
 	t.Run("should return nil and error for non existent file", func(t *testing.T) {
 		var nonExistentFile = "nonexistent.cell"
 		fileStream, _ := file.New(nonExistentFile)
-		var expectedError = file.NotFoundFileError
+		var expectedError = nonExistentFile + ": " + file.NotFoundFileError + ": no such file or directory"
 
 		actualGeneration, actualError := fileStream.Read()
 
@@ -134,6 +138,45 @@ func TestRead(t *testing.T) {
 		assert.EqualError(t, actualError, expectedError)
 	})
 
+	t.Run("should return error telling non existent file apart", func(t *testing.T) {
+		var nonExistentFile = "nonexistent.cell"
+		fileStream, _ := file.New(nonExistentFile)
+
+		_, actualError := fileStream.Read()
+
+		var fileError *io.FileError
+		assert.True(t, errors.Is(actualError, file.ErrNotFoundFile))
+		assert.True(t, errors.Is(actualError, fs.ErrNotExist))
+		assert.False(t, errors.Is(actualError, file.ErrInvalidFormat))
+		if assert.True(t, errors.As(actualError, &fileError)) {
+			assert.Equal(t, nonExistentFile, fileError.Path)
+		}
+	})
+
+	t.Run("should return nil and error wrapping the cause of an unreadable file", func(t *testing.T) {
+		directory, err := ioutil.TempDir("", "cell")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "directory.cell")
+		if err := os.Mkdir(path, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		fileStream, _ := file.New(path)
+
+		actualGeneration, actualError := fileStream.Read()
+
+		var fileError *io.FileError
+		assert.Nil(t, actualGeneration)
+		assert.True(t, errors.Is(actualError, io.ErrUnreadableFile))
+		assert.False(t, errors.Is(actualError, file.ErrNotFoundFile))
+		if assert.True(t, errors.As(actualError, &fileError)) {
+			assert.Equal(t, path, fileError.Path)
+			assert.NotNil(t, fileError.Cause)
+		}
+	})
+
 	t.Run("should return nil and error for empty file", func(t *testing.T) {
 		path := fmt.Sprintf("%s%s", cellDirectory, emptyCell)
 		fileStream, _ := file.New(path)
@@ -148,12 +191,19 @@ func TestRead(t *testing.T) {
 	t.Run("should return nil and error for invalid format", func(t *testing.T) {
 		path := fmt.Sprintf("%s%s", cellDirectory, invalidCell)
 		fileStream, _ := file.New(path)
-		var expectedError = file.InvalidFormatError
+		var expectedError = &io.ParseError{
+			Path:     path,
+			Line:     1,
+			Column:   5,
+			Found:    'x',
+			Expected: "-o",
+			Err:      file.ErrInvalidFormat,
+		}
 
 		actualGeneration, actualError := fileStream.Read()
 
 		assert.Nil(t, actualGeneration)
-		assert.EqualError(t, actualError, expectedError)
+		assert.Equal(t, expectedError, actualError)
 	})
 
 	t.Run("should return generation and nil for valid file", func(t *testing.T) {
@@ -166,6 +216,40 @@ func TestRead(t *testing.T) {
 		assert.EqualValues(t, expectedGeneration, actualGeneration)
 		assert.Nil(t, actualError)
 	})
+
+	t.Run("should read CRLF line endings and trailing new lines", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, "crlf.cell")
+		_ = ioutil.WriteFile(path, []byte("-o-\r\no-o\r\n-o-\r\n\r\n"), os.ModePerm)
+		defer os.Remove(path)
+		fileStream, _ := file.New(path)
+		expectedGeneration := tubGeneration
+
+		actualGeneration, actualError := fileStream.Read()
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedGeneration, actualGeneration)
+	})
+
+	t.Run("should return nil and error at the position of an uneven row", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, "uneven.cell")
+		_ = ioutil.WriteFile(path, []byte("-o-\no-\n-o--"), os.ModePerm)
+		defer os.Remove(path)
+		fileStream, _ := file.New(path)
+		var expectedError = &io.ParseError{
+			Path:     path,
+			Line:     2,
+			Column:   3,
+			Found:    io.EndOfLine,
+			Expected: "-o",
+			Err:      file.ErrUnevenRow,
+		}
+
+		actualGeneration, actualError := fileStream.Read()
+
+		assert.Nil(t, actualGeneration)
+		assert.Equal(t, expectedError, actualError)
+		assert.EqualError(t, actualError, path+":2:3: "+file.UnevenRowError+", found end of line, expected one of \"-o\"")
+	})
 }
 
 func TestWrite(t *testing.T) {
@@ -205,3 +289,131 @@ func TestWrite(t *testing.T) {
 		assert.Equal(t, expectedGeneration, string(actualGeneration))
 	})
 }
+
+func TestReadStates(t *testing.T) {
+	t.Run("should return nil and error for non existent file", func(t *testing.T) {
+		fileStream, _ := file.New("nonexistent.cell")
+		var expectedError = nonExistentFile + ": " + file.NotFoundFileError + ": no such file or directory"
+
+		actualStates, actualError := fileStream.ReadStates()
+
+		assert.Nil(t, actualStates)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid state", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, invalidCell)
+		fileStream, _ := file.New(path)
+		var expectedError = &io.ParseError{
+			Path:     path,
+			Line:     1,
+			Column:   5,
+			Found:    'x',
+			Expected: cell.StateCharacters,
+			Err:      file.ErrInvalidState,
+		}
+
+		actualStates, actualError := fileStream.ReadStates()
+
+		assert.Nil(t, actualStates)
+		assert.Equal(t, expectedError, actualError)
+	})
+
+	t.Run("should return states and nil for valid file", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, "states.cell")
+		_ = ioutil.WriteFile(path, []byte("o2-\n-3A"), os.ModePerm)
+		defer os.Remove(path)
+		fileStream, _ := file.New(path)
+		expectedStates := [][]uint8{{1, 2, 0}, {0, 3, 10}}
+
+		actualStates, actualError := fileStream.ReadStates()
+
+		assert.EqualValues(t, expectedStates, actualStates)
+		assert.Nil(t, actualError)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should return error for non existent file", func(t *testing.T) {
+		fileStream, _ := file.New("nonexistent.cell")
+
+		actualErrors := fileStream.Validate(2)
+
+		assert.Len(t, actualErrors, 1)
+		assert.EqualError(t, actualErrors[0], file.NotFoundFileError)
+	})
+
+	t.Run("should return nil for valid file", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, tubCell)
+		fileStream, _ := file.New(path)
+
+		actualErrors := fileStream.Validate(2)
+
+		assert.Nil(t, actualErrors)
+	})
+
+	t.Run("should return every problem of the file", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, "problems.cell")
+		_ = ioutil.WriteFile(path, []byte("-o2\n\nox-o\n-o"), os.ModePerm)
+		defer os.Remove(path)
+		fileStream, _ := file.New(path)
+		var expectedErrors = []error{
+			&io.ParseError{Path: path, Line: 1, Column: 3, Found: '2', Expected: "-o", Err: file.ErrInvalidFormat},
+			&io.ParseError{Path: path, Line: 2, Column: 1, Found: io.EndOfLine, Expected: "-o", Err: file.ErrEmptyRow},
+			&io.ParseError{Path: path, Line: 3, Column: 2, Found: 'x', Expected: "-o", Err: file.ErrInvalidFormat},
+			&io.ParseError{Path: path, Line: 3, Column: 4, Found: 'o', Expected: "\n", Err: file.ErrUnevenRow},
+			&io.ParseError{Path: path, Line: 4, Column: 3, Found: io.EndOfLine, Expected: "-o", Err: file.ErrUnevenRow},
+		}
+
+		actualErrors := fileStream.Validate(2)
+
+		assert.Equal(t, expectedErrors, actualErrors)
+	})
+
+	t.Run("should accept the states of the rule", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, "decay.cell")
+		_ = ioutil.WriteFile(path, []byte("o2-\n-3o"), os.ModePerm)
+		defer os.Remove(path)
+		fileStream, _ := file.New(path)
+
+		fourStateErrors := fileStream.Validate(4)
+		threeStateErrors := fileStream.Validate(3)
+
+		assert.Nil(t, fourStateErrors)
+		assert.Len(t, threeStateErrors, 1)
+	})
+}
+
+func TestWriteStates(t *testing.T) {
+	t.Run("should return error for nil states", func(t *testing.T) {
+		fileStream, _ := file.New("states.cell")
+		var expectedError = file.NilGenerationError
+
+		actualError := fileStream.WriteStates(nil)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for state without character", func(t *testing.T) {
+		fileStream, _ := file.New("states.cell")
+		var expectedError = file.InvalidStateError
+
+		actualError := fileStream.WriteStates([][]uint8{{1, 200}})
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil for valid states", func(t *testing.T) {
+		path := fmt.Sprintf("%s%s", cellDirectory, "states.cell")
+		defer os.Remove(path)
+		fileStream, _ := file.New(path)
+		var expectedStates string = "o2-\n-3A"
+
+		actualError := fileStream.WriteStates([][]uint8{{1, 2, 0}, {0, 3, 10}})
+		actualStates, err := ioutil.ReadFile(path)
+
+		assert.Nil(t, actualError)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedStates, string(actualStates))
+	})
+}
@@ -0,0 +1,50 @@
+package io
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	UnreadableFileError = "file cannot be read"
+)
+
+var (
+	// ErrUnreadableFile is the error of a FileError for a file that exists
+	// but cannot be read, e.g. for lack of permission.
+	ErrUnreadableFile = errors.New(UnreadableFileError)
+)
+
+// FileError is a file at Path that cannot be read as it should, Err being
+// the error of the stream telling what the problem is and Cause the error
+// underneath it, e.g. of the file system or of decoding.
+//
+// It is Err as told by errors.Is while it unwraps to Cause, so both the
+// problem and its cause can be told apart.
+type FileError struct {
+	Path  string
+	Err   error
+	Cause error
+}
+
+func (fileError *FileError) Error() string {
+	if fileError.Cause == nil {
+		return fmt.Sprintf("%s: %s", fileError.Path, fileError.Err)
+	}
+
+	// the cause of a failed open already holds the path
+	cause := fileError.Cause
+	if pathError, isPathError := cause.(*os.PathError); isPathError {
+		cause = pathError.Err
+	}
+	return fmt.Sprintf("%s: %s: %s", fileError.Path, fileError.Err, cause)
+}
+
+func (fileError *FileError) Is(target error) bool {
+	return target == fileError.Err
+}
+
+func (fileError *FileError) Unwrap() error {
+	return fileError.Cause
+}
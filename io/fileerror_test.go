@@ -0,0 +1,70 @@
+package io_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileError(t *testing.T) {
+	var errNotFound = errors.New("file is not found")
+
+	t.Run("should return its path, its error and its cause", func(t *testing.T) {
+		fileError := &io.FileError{Path: "glider.cell", Err: errNotFound, Cause: fs.ErrNotExist}
+
+		assert.EqualError(t, fileError, "glider.cell: file is not found: file does not exist")
+	})
+
+	t.Run("should return its path and its error without cause", func(t *testing.T) {
+		fileError := &io.FileError{Path: "glider.cell", Err: errNotFound}
+
+		assert.EqualError(t, fileError, "glider.cell: file is not found")
+	})
+
+	t.Run("should leave out the path repeated by the cause", func(t *testing.T) {
+		fileError := &io.FileError{Path: "glider.cell", Err: errNotFound, Cause: &fs.PathError{Op: "open", Path: "glider.cell", Err: fs.ErrPermission}}
+
+		assert.EqualError(t, fileError, "glider.cell: file is not found: permission denied")
+	})
+
+	t.Run("should be both its error and its cause", func(t *testing.T) {
+		var err error = &io.FileError{Path: "glider.cell", Err: errNotFound, Cause: &fs.PathError{Op: "open", Path: "glider.cell", Err: fs.ErrNotExist}}
+
+		var pathError *fs.PathError
+		assert.True(t, errors.Is(err, errNotFound))
+		assert.True(t, errors.Is(err, fs.ErrNotExist))
+		assert.True(t, errors.As(err, &pathError))
+		assert.False(t, errors.Is(err, fs.ErrPermission))
+	})
+
+	t.Run("should be found through wrapping errors", func(t *testing.T) {
+		var err error = &io.FileError{Path: "composition.json", Err: errNotFound, Cause: &json.SyntaxError{}}
+		wrapped := &io.ParseError{Path: "composition.json", Line: 1, Column: 1, Err: err}
+
+		var fileError *io.FileError
+		var syntaxError *json.SyntaxError
+		assert.True(t, errors.As(wrapped, &fileError))
+		assert.Equal(t, "composition.json", fileError.Path)
+		assert.True(t, errors.As(wrapped, &syntaxError))
+	})
+}
+
+func TestParseError(t *testing.T) {
+	var errInvalidFormat = errors.New("generation is invalid")
+
+	t.Run("should return its position, its error and what was found", func(t *testing.T) {
+		parseError := &io.ParseError{Path: "glider.cell", Line: 2, Column: 3, Found: 'x', Expected: "-o", Err: errInvalidFormat}
+
+		assert.EqualError(t, parseError, `glider.cell:2:3: generation is invalid, found 'x', expected one of "-o"`)
+	})
+
+	t.Run("should be its error", func(t *testing.T) {
+		var err error = &io.ParseError{Path: "glider.cell", Line: 1, Column: 1, Found: io.EndOfLine, Err: errInvalidFormat}
+
+		assert.True(t, errors.Is(err, errInvalidFormat))
+	})
+}
@@ -0,0 +1,49 @@
+package io
+
+import (
+	"fmt"
+)
+
+const (
+	// EndOfLine stands for the end of a line in Found and Expected of a
+	// ParseError.
+	EndOfLine = '\n'
+)
+
+// ParseError is a problem at a position of a pattern file, its line and
+// column counted from 1. Found is the byte at the position, 0 when there is
+// none to blame, and Expected holds the bytes that would have been read.
+// Err is the error of the stream telling what the problem is.
+type ParseError struct {
+	Path     string
+	Line     int
+	Column   int
+	Found    byte
+	Expected string
+	Err      error
+}
+
+func (parseError *ParseError) Error() string {
+	position := fmt.Sprintf("%s:%d:%d: %s", parseError.Path, parseError.Line, parseError.Column, parseError.Err)
+	switch {
+	case parseError.Found == 0:
+		return position
+	case parseError.Expected == "":
+		return fmt.Sprintf("%s, found %s", position, describe(parseError.Found))
+	case parseError.Expected == string(EndOfLine):
+		return fmt.Sprintf("%s, found %s, expected end of line", position, describe(parseError.Found))
+	default:
+		return fmt.Sprintf("%s, found %s, expected one of %q", position, describe(parseError.Found), parseError.Expected)
+	}
+}
+
+func (parseError *ParseError) Unwrap() error {
+	return parseError.Err
+}
+
+func describe(character byte) string {
+	if character == EndOfLine {
+		return "end of line"
+	}
+	return fmt.Sprintf("%q", character)
+}
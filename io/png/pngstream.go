@@ -0,0 +1,173 @@
+package png
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	imagepng "image/png"
+	"os"
+	"path/filepath"
+)
+
+const (
+	FileExtension = ".png"
+)
+
+const (
+	PathEmptyError        = "path passed is empty"
+	InvalidExtensionError = "invalid file extension (file should be *.png)"
+	NilGenerationError    = "generation is nil"
+	EmptyGenerationError  = "generation is empty"
+	NegativeHeatError     = "heat is negative"
+)
+
+var (
+	ErrPathEmpty        = errors.New(PathEmptyError)
+	ErrInvalidExtension = errors.New(InvalidExtensionError)
+	ErrNilGeneration    = errors.New(NilGenerationError)
+	ErrEmptyGeneration  = errors.New(EmptyGenerationError)
+	ErrNegativeHeat     = errors.New(NegativeHeatError)
+)
+
+const (
+	cellSize = 8
+)
+
+var (
+	deadColor  = color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff}
+	aliveColor = color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+	// the heat of a cell goes from cold to hot as it nears the most heat
+	coldColor = color.RGBA{R: 0x20, G: 0x30, B: 0xc0, A: 0xff}
+	hotColor  = color.RGBA{R: 0xff, G: 0xd0, B: 0x20, A: 0xff}
+)
+
+// PNGStream writes a generation as an image, each cell a square of eight
+// pixels a side.
+type PNGStream struct {
+	path string
+}
+
+func (pngStream *PNGStream) Write(generation [][]bool) error {
+	if generation == nil {
+		return ErrNilGeneration
+	}
+	if len(generation) == 0 {
+		return ErrEmptyGeneration
+	}
+
+	return pngStream.WriteCells(len(generation), len(generation[0]), func(i, j int) color.RGBA {
+		if generation[i][j] {
+			return aliveColor
+		}
+		return deadColor
+	})
+}
+
+// WriteStates writes the states after alive, e.g. the decaying states of
+// Generations, fading from alive to dead.
+func (pngStream *PNGStream) WriteStates(states [][]uint8) error {
+	if states == nil {
+		return ErrNilGeneration
+	}
+	if len(states) == 0 {
+		return ErrEmptyGeneration
+	}
+
+	maxState := uint8(1)
+	for i := range states {
+		for j := range states[i] {
+			if states[i][j] > maxState {
+				maxState = states[i][j]
+			}
+		}
+	}
+
+	return pngStream.WriteCells(len(states), len(states[0]), func(i, j int) color.RGBA {
+		if states[i][j] == 0 {
+			return deadColor
+		}
+		return blend(aliveColor, deadColor, float64(states[i][j]-1)/float64(maxState))
+	})
+}
+
+// WriteHeat writes a value for each cell, e.g. its age or how often it has
+// been alive, from cold to hot up to the largest value and dead for 0.
+func (pngStream *PNGStream) WriteHeat(heat [][]int) error {
+	if heat == nil {
+		return ErrNilGeneration
+	}
+	if len(heat) == 0 {
+		return ErrEmptyGeneration
+	}
+
+	maxHeat := 1
+	for i := range heat {
+		for j := range heat[i] {
+			if heat[i][j] < 0 {
+				return ErrNegativeHeat
+			}
+			if heat[i][j] > maxHeat {
+				maxHeat = heat[i][j]
+			}
+		}
+	}
+
+	return pngStream.WriteCells(len(heat), len(heat[0]), func(i, j int) color.RGBA {
+		if heat[i][j] == 0 {
+			return deadColor
+		}
+		if maxHeat == 1 {
+			return hotColor
+		}
+		return blend(coldColor, hotColor, float64(heat[i][j]-1)/float64(maxHeat-1))
+	})
+}
+
+// WriteCells writes numOfRows by numOfCols cells, each in the color that
+// cellColor gives it.
+func (pngStream *PNGStream) WriteCells(numOfRows, numOfCols int, cellColor func(i, j int) color.RGBA) error {
+	cellImage := image.NewRGBA(image.Rect(0, 0, numOfCols*cellSize, numOfRows*cellSize))
+	for i := 0; i < numOfRows; i++ {
+		for j := 0; j < numOfCols; j++ {
+			currentColor := cellColor(i, j)
+			for y := i * cellSize; y < (i+1)*cellSize; y++ {
+				for x := j * cellSize; x < (j+1)*cellSize; x++ {
+					cellImage.SetRGBA(x, y, currentColor)
+				}
+			}
+		}
+	}
+
+	imageFile, err := os.Create(pngStream.path)
+	if err != nil {
+		return err
+	}
+	if err = imagepng.Encode(imageFile, cellImage); err != nil {
+		imageFile.Close()
+		return err
+	}
+	return imageFile.Close()
+}
+
+// blend returns the color ratio of the way from one color to other.
+func blend(from, to color.RGBA, ratio float64) color.RGBA {
+	mix := func(fromValue, toValue uint8) uint8 {
+		return uint8(float64(fromValue) + (float64(toValue)-float64(fromValue))*ratio)
+	}
+	return color.RGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: 0xff}
+}
+
+func New(path string) (*PNGStream, error) {
+	if path == "" {
+		return nil, ErrPathEmpty
+	}
+	if filepath.Ext(path) != FileExtension {
+		return nil, ErrInvalidExtension
+	}
+
+	var pngStream = PNGStream{
+		path: path,
+	}
+	return &pngStream, nil
+}
@@ -0,0 +1,165 @@
+package png_test
+
+import (
+	"image"
+	"image/color"
+	imagepng "image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/io/png"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	gliderGeneration = [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+)
+
+func makePath(t *testing.T) (string, func()) {
+	directory, err := ioutil.TempDir("", "png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(directory, "output.png"), func() { os.RemoveAll(directory) }
+}
+
+func decodeImage(t *testing.T, path string) image.Image {
+	imageFile, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer imageFile.Close()
+
+	decodedImage, err := imagepng.Decode(imageFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decodedImage
+}
+
+func brightness(cellImage image.Image, x, y int) uint32 {
+	red, green, blue, _ := cellImage.At(x, y).RGBA()
+	return red + green + blue
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for empty path", func(t *testing.T) {
+		actualStream, actualError := png.New("")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, png.PathEmptyError)
+	})
+
+	t.Run("should return nil and error for invalid file extension", func(t *testing.T) {
+		actualStream, actualError := png.New("output.cell")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, png.InvalidExtensionError)
+	})
+
+	t.Run("should return png stream and nil for valid file extension", func(t *testing.T) {
+		actualStream, actualError := png.New("output.png")
+
+		assert.NotNil(t, actualStream)
+		assert.Nil(t, actualError)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("should return error for nil or empty generation", func(t *testing.T) {
+		pngStream, _ := png.New("output.png")
+
+		assert.EqualError(t, pngStream.Write(nil), png.NilGenerationError)
+		assert.EqualError(t, pngStream.Write([][]bool{}), png.EmptyGenerationError)
+	})
+
+	t.Run("should write image of eight pixels a cell", func(t *testing.T) {
+		path, cleanUp := makePath(t)
+		defer cleanUp()
+		pngStream, _ := png.New(path)
+
+		actualError := pngStream.Write(gliderGeneration)
+
+		assert.Nil(t, actualError)
+		actualImage := decodeImage(t, path)
+		assert.Equal(t, 24, actualImage.Bounds().Dx())
+		assert.Equal(t, 24, actualImage.Bounds().Dy())
+		assert.Greater(t, brightness(actualImage, 12, 4), brightness(actualImage, 4, 4))
+	})
+}
+
+func TestWriteStates(t *testing.T) {
+	t.Run("should return error for nil or empty states", func(t *testing.T) {
+		pngStream, _ := png.New("output.png")
+
+		assert.EqualError(t, pngStream.WriteStates(nil), png.NilGenerationError)
+		assert.EqualError(t, pngStream.WriteStates([][]uint8{}), png.EmptyGenerationError)
+	})
+
+	t.Run("should fade decaying states from alive to dead", func(t *testing.T) {
+		path, cleanUp := makePath(t)
+		defer cleanUp()
+		pngStream, _ := png.New(path)
+
+		actualError := pngStream.WriteStates([][]uint8{{1, 2, 0}})
+
+		assert.Nil(t, actualError)
+		actualImage := decodeImage(t, path)
+		assert.Greater(t, brightness(actualImage, 4, 4), brightness(actualImage, 12, 4))
+		assert.Greater(t, brightness(actualImage, 12, 4), brightness(actualImage, 20, 4))
+	})
+}
+
+func TestWriteHeat(t *testing.T) {
+	t.Run("should return error for nil, empty or negative heat", func(t *testing.T) {
+		pngStream, _ := png.New("output.png")
+
+		assert.EqualError(t, pngStream.WriteHeat(nil), png.NilGenerationError)
+		assert.EqualError(t, pngStream.WriteHeat([][]int{}), png.EmptyGenerationError)
+		assert.EqualError(t, pngStream.WriteHeat([][]int{{1, -1}}), png.NegativeHeatError)
+	})
+
+	t.Run("should write cold to hot up to the largest heat", func(t *testing.T) {
+		path, cleanUp := makePath(t)
+		defer cleanUp()
+		pngStream, _ := png.New(path)
+
+		actualError := pngStream.WriteHeat([][]int{{1, 3, 0}})
+
+		assert.Nil(t, actualError)
+		actualImage := decodeImage(t, path)
+		coldRed, _, coldBlue, _ := actualImage.At(4, 4).RGBA()
+		hotRed, _, hotBlue, _ := actualImage.At(12, 4).RGBA()
+		assert.Greater(t, coldBlue, coldRed)
+		assert.Greater(t, hotRed, hotBlue)
+		assert.Greater(t, brightness(actualImage, 4, 4), brightness(actualImage, 20, 4))
+	})
+}
+
+func TestWriteCells(t *testing.T) {
+	t.Run("should write each cell in its own color", func(t *testing.T) {
+		path, cleanUp := makePath(t)
+		defer cleanUp()
+		pngStream, _ := png.New(path)
+		var expectedColor = color.RGBA{R: 0x30, G: 0xc0, B: 0x40, A: 0xff}
+
+		actualError := pngStream.WriteCells(1, 2, func(i, j int) color.RGBA {
+			if j == 1 {
+				return expectedColor
+			}
+			return color.RGBA{A: 0xff}
+		})
+
+		assert.Nil(t, actualError)
+		actualImage := decodeImage(t, path)
+		assert.Equal(t, 16, actualImage.Bounds().Dx())
+		assert.Equal(t, 8, actualImage.Bounds().Dy())
+		assert.Equal(t, expectedColor, color.RGBAModel.Convert(actualImage.At(12, 4)))
+	})
+}
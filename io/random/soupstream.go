@@ -0,0 +1,216 @@
+package random
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+const (
+	InvalidWidthError    = "width is less than one (should be at least 1)"
+	InvalidHeightError   = "height is less than one (should be at least 1)"
+	InvalidDensityError  = "density is out of range (should be more than 0 and at most 1)"
+	UnknownSymmetryError = "unknown symmetry (use: C1, C2_1, C2_2, C2_4, C4_1, C4_4, D2_+1, D2_+2, D2_x, D4_+1, D4_+2, D4_+4, D4_x1, D4_x4, D8_1, D8_4)"
+)
+
+var (
+	ErrInvalidWidth    = errors.New(InvalidWidthError)
+	ErrInvalidHeight   = errors.New(InvalidHeightError)
+	ErrInvalidDensity  = errors.New(InvalidDensityError)
+	ErrUnknownSymmetry = errors.New(UnknownSymmetryError)
+)
+
+const (
+	NoSymmetry = "C1"
+)
+
+const (
+	parityAny = iota
+	parityOdd
+	parityEven
+)
+
+type transformation func(row, col, height, width int) (int, int)
+
+type symmetry struct {
+	isSquare        bool
+	heightParity    int
+	widthParity     int
+	transformations []transformation
+}
+
+var (
+	rotate90 = func(row, col, height, width int) (int, int) {
+		return col, height - 1 - row
+	}
+	rotate180 = func(row, col, height, width int) (int, int) {
+		return height - 1 - row, width - 1 - col
+	}
+	rotate270 = func(row, col, height, width int) (int, int) {
+		return width - 1 - col, row
+	}
+	flipRows = func(row, col, height, width int) (int, int) {
+		return height - 1 - row, col
+	}
+	flipCols = func(row, col, height, width int) (int, int) {
+		return row, width - 1 - col
+	}
+	transpose = func(row, col, height, width int) (int, int) {
+		return col, row
+	}
+	antiTranspose = func(row, col, height, width int) (int, int) {
+		return width - 1 - col, height - 1 - row
+	}
+
+	c2  = []transformation{rotate180}
+	c4  = []transformation{rotate90, rotate180, rotate270}
+	d2p = []transformation{flipRows}
+	d2x = []transformation{transpose}
+	d4p = []transformation{flipRows, flipCols, rotate180}
+	d4x = []transformation{transpose, antiTranspose, rotate180}
+	d8  = []transformation{rotate90, rotate180, rotate270, flipRows, flipCols, transpose, antiTranspose}
+
+	// the suffix of a symmetry name tells where its centre lies: on a cell (1),
+	// on an edge (2) or on a corner (4), which fixes the parity of each side
+	symmetries = map[string]symmetry{
+		NoSymmetry: {},
+		"C2_1":     {heightParity: parityOdd, widthParity: parityOdd, transformations: c2},
+		"C2_2":     {heightParity: parityEven, widthParity: parityOdd, transformations: c2},
+		"C2_4":     {heightParity: parityEven, widthParity: parityEven, transformations: c2},
+		"C4_1":     {isSquare: true, heightParity: parityOdd, widthParity: parityOdd, transformations: c4},
+		"C4_4":     {isSquare: true, heightParity: parityEven, widthParity: parityEven, transformations: c4},
+		"D2_+1":    {heightParity: parityOdd, transformations: d2p},
+		"D2_+2":    {heightParity: parityEven, transformations: d2p},
+		"D2_x":     {isSquare: true, transformations: d2x},
+		"D4_+1":    {heightParity: parityOdd, widthParity: parityOdd, transformations: d4p},
+		"D4_+2":    {heightParity: parityEven, widthParity: parityOdd, transformations: d4p},
+		"D4_+4":    {heightParity: parityEven, widthParity: parityEven, transformations: d4p},
+		"D4_x1":    {isSquare: true, heightParity: parityOdd, widthParity: parityOdd, transformations: d4x},
+		"D4_x4":    {isSquare: true, heightParity: parityEven, widthParity: parityEven, transformations: d4x},
+		"D8_1":     {isSquare: true, heightParity: parityOdd, widthParity: parityOdd, transformations: d8},
+		"D8_4":     {isSquare: true, heightParity: parityEven, widthParity: parityEven, transformations: d8},
+	}
+)
+
+type SoupStream struct {
+	width    int
+	height   int
+	density  float64
+	seed     int64
+	symmetry string
+}
+
+func (soupStream *SoupStream) GetWidth() int {
+	return soupStream.width
+}
+
+func (soupStream *SoupStream) GetHeight() int {
+	return soupStream.height
+}
+
+func (soupStream *SoupStream) GetDensity() float64 {
+	return soupStream.density
+}
+
+func (soupStream *SoupStream) GetSeed() int64 {
+	return soupStream.seed
+}
+
+func (soupStream *SoupStream) GetSymmetry() string {
+	return soupStream.symmetry
+}
+
+// Read returns the soup of the stream seed, so reading twice gives the same soup.
+func (soupStream *SoupStream) Read() ([][]bool, error) {
+	random := rand.New(rand.NewSource(soupStream.seed))
+	transformations := symmetries[soupStream.symmetry].transformations
+
+	soup := make([][]bool, soupStream.height)
+	for i := 0; i < soupStream.height; i++ {
+		soup[i] = make([]bool, soupStream.width)
+		for j := 0; j < soupStream.width; j++ {
+			representativeRow, representativeCol := i, j
+			for _, transform := range transformations {
+				row, col := transform(i, j, soupStream.height, soupStream.width)
+				if row < representativeRow || row == representativeRow && col < representativeCol {
+					representativeRow, representativeCol = row, col
+				}
+			}
+
+			if representativeRow == i && representativeCol == j {
+				soup[i][j] = random.Float64() < soupStream.density
+			} else {
+				soup[i][j] = soup[representativeRow][representativeCol]
+			}
+		}
+	}
+
+	return soup, nil
+}
+
+func New(width, height int, density float64, seed int64, symmetryName string) (*SoupStream, error) {
+	if width < 1 {
+		return nil, ErrInvalidWidth
+	}
+	if height < 1 {
+		return nil, ErrInvalidHeight
+	}
+	if density <= 0 || density > 1 {
+		return nil, ErrInvalidDensity
+	}
+	if symmetryName == "" {
+		symmetryName = NoSymmetry
+	}
+	soupSymmetry, isKnown := symmetries[symmetryName]
+	if !isKnown {
+		soupSymmetry, isKnown = symmetries[strings.ToUpper(symmetryName[:1])+symmetryName[1:]]
+		if !isKnown {
+			return nil, ErrUnknownSymmetry
+		}
+		symmetryName = strings.ToUpper(symmetryName[:1]) + symmetryName[1:]
+	}
+
+	if soupSymmetry.isSquare {
+		if width < height {
+			height = width
+		} else {
+			width = height
+		}
+	}
+	height = fitParity(height, soupSymmetry.heightParity)
+	width = fitParity(width, soupSymmetry.widthParity)
+
+	var soupStream = SoupStream{
+		width:    width,
+		height:   height,
+		density:  density,
+		seed:     seed,
+		symmetry: symmetryName,
+	}
+	return &soupStream, nil
+}
+
+func GetSymmetries() []string {
+	names := make([]string, 0, len(symmetries))
+	for name := range symmetries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// fitParity shrinks length by one when it does not match parity, growing
+// instead when shrinking would leave nothing.
+func fitParity(length int, parity int) int {
+	isEven := length%2 == 0
+	if parity == parityOdd && isEven || parity == parityEven && !isEven {
+		if length == 1 {
+			return length + 1
+		}
+		return length - 1
+	}
+
+	return length
+}
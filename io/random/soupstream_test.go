@@ -0,0 +1,184 @@
+package random_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/io/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func isSymmetric(soup [][]bool, transform func(row, col, height, width int) (int, int)) bool {
+	height := len(soup)
+	width := len(soup[0])
+	for i := 0; i < height; i++ {
+		for j := 0; j < width; j++ {
+			row, col := transform(i, j, height, width)
+			if soup[i][j] != soup[row][col] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func rotate90(row, col, height, width int) (int, int) {
+	return col, height - 1 - row
+}
+
+func rotate180(row, col, height, width int) (int, int) {
+	return height - 1 - row, width - 1 - col
+}
+
+func flipRows(row, col, height, width int) (int, int) {
+	return height - 1 - row, col
+}
+
+func flipCols(row, col, height, width int) (int, int) {
+	return row, width - 1 - col
+}
+
+func transpose(row, col, height, width int) (int, int) {
+	return col, row
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for width less than one", func(t *testing.T) {
+		var expectedError = random.InvalidWidthError
+
+		actualSoupStream, actualError := random.New(0, 16, 0.5, 42, "")
+
+		assert.Nil(t, actualSoupStream)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for height less than one", func(t *testing.T) {
+		var expectedError = random.InvalidHeightError
+
+		actualSoupStream, actualError := random.New(16, 0, 0.5, 42, "")
+
+		assert.Nil(t, actualSoupStream)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for density out of range", func(t *testing.T) {
+		var expectedError = random.InvalidDensityError
+
+		_, zeroError := random.New(16, 16, 0, 42, "")
+		_, overError := random.New(16, 16, 1.5, 42, "")
+
+		assert.EqualError(t, zeroError, expectedError)
+		assert.EqualError(t, overError, expectedError)
+	})
+
+	t.Run("should return nil and error for unknown symmetry", func(t *testing.T) {
+		var expectedError = random.UnknownSymmetryError
+
+		actualSoupStream, actualError := random.New(16, 16, 0.5, 42, "C3")
+
+		assert.Nil(t, actualSoupStream)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should use no symmetry by default", func(t *testing.T) {
+		actualSoupStream, actualError := random.New(16, 8, 0.5, 42, "")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, random.NoSymmetry, actualSoupStream.GetSymmetry())
+		assert.Equal(t, 16, actualSoupStream.GetWidth())
+		assert.Equal(t, 8, actualSoupStream.GetHeight())
+	})
+
+	t.Run("should fit size to symmetry centre", func(t *testing.T) {
+		oddStream, _ := random.New(16, 16, 0.5, 42, "C2_1")
+		squareStream, _ := random.New(16, 12, 0.5, 42, "D8_4")
+
+		assert.Equal(t, 15, oddStream.GetWidth())
+		assert.Equal(t, 15, oddStream.GetHeight())
+		assert.Equal(t, 12, squareStream.GetWidth())
+		assert.Equal(t, 12, squareStream.GetHeight())
+	})
+}
+
+func TestRead(t *testing.T) {
+	t.Run("should return soup of the given size", func(t *testing.T) {
+		soupStream, _ := random.New(7, 5, 0.5, 42, "")
+
+		actualSoup, actualError := soupStream.Read()
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualSoup, 5)
+		assert.Len(t, actualSoup[0], 7)
+	})
+
+	t.Run("should return the same soup for the same seed", func(t *testing.T) {
+		soupStream, _ := random.New(16, 16, 0.5, 42, "")
+		otherSoupStream, _ := random.New(16, 16, 0.5, 42, "")
+
+		actualSoup, _ := soupStream.Read()
+		otherSoup, _ := otherSoupStream.Read()
+
+		assert.EqualValues(t, actualSoup, otherSoup)
+	})
+
+	t.Run("should return different soup for different seed", func(t *testing.T) {
+		soupStream, _ := random.New(16, 16, 0.5, 42, "")
+		otherSoupStream, _ := random.New(16, 16, 0.5, 43, "")
+
+		actualSoup, _ := soupStream.Read()
+		otherSoup, _ := otherSoupStream.Read()
+
+		assert.NotEqual(t, actualSoup, otherSoup)
+	})
+
+	t.Run("should fill every cell for full density", func(t *testing.T) {
+		soupStream, _ := random.New(4, 4, 1, 42, "")
+
+		actualSoup, _ := soupStream.Read()
+
+		for i := 0; i < len(actualSoup); i++ {
+			for j := 0; j < len(actualSoup[i]); j++ {
+				assert.True(t, actualSoup[i][j])
+			}
+		}
+	})
+
+	t.Run("should follow symmetry", func(t *testing.T) {
+		checkList := []struct {
+			symmetry   string
+			transforms []func(row, col, height, width int) (int, int)
+		}{
+			{"C2_1", []func(int, int, int, int) (int, int){rotate180}},
+			{"C2_2", []func(int, int, int, int) (int, int){rotate180}},
+			{"C2_4", []func(int, int, int, int) (int, int){rotate180}},
+			{"C4_1", []func(int, int, int, int) (int, int){rotate90}},
+			{"C4_4", []func(int, int, int, int) (int, int){rotate90}},
+			{"D2_+1", []func(int, int, int, int) (int, int){flipRows}},
+			{"D2_+2", []func(int, int, int, int) (int, int){flipRows}},
+			{"D2_x", []func(int, int, int, int) (int, int){transpose}},
+			{"D4_+1", []func(int, int, int, int) (int, int){flipRows, flipCols}},
+			{"D4_+4", []func(int, int, int, int) (int, int){flipRows, flipCols}},
+			{"D4_x4", []func(int, int, int, int) (int, int){transpose, rotate180}},
+			{"D8_1", []func(int, int, int, int) (int, int){rotate90, flipRows}},
+			{"D8_4", []func(int, int, int, int) (int, int){rotate90, flipRows}},
+		}
+		for _, check := range checkList {
+			soupStream, _ := random.New(16, 16, 0.5, 42, check.symmetry)
+
+			actualSoup, _ := soupStream.Read()
+
+			for _, transform := range check.transforms {
+				assert.True(t, isSymmetric(actualSoup, transform), check.symmetry)
+			}
+		}
+	})
+}
+
+func TestGetSymmetries(t *testing.T) {
+	t.Run("should list every symmetry", func(t *testing.T) {
+		actualSymmetries := random.GetSymmetries()
+
+		assert.Contains(t, actualSymmetries, random.NoSymmetry)
+		assert.Contains(t, actualSymmetries, "D8_4")
+		assert.Len(t, actualSymmetries, 16)
+	})
+}
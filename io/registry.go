@@ -0,0 +1,140 @@
+package io
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+const (
+	EmptyNameError      = "name of the stream is empty"
+	NilFactoryError     = "factory of the stream is nil"
+	RegisteredNameError = "name of the stream is already registered"
+	ReservedNameError   = "name of the stream is reserved (file, custom, random and library are built in)"
+	UnknownReaderError  = "no reader is registered with the name"
+	UnknownWriterError  = "no writer is registered with the name"
+)
+
+var (
+	ErrEmptyName      = errors.New(EmptyNameError)
+	ErrNilFactory     = errors.New(NilFactoryError)
+	ErrRegisteredName = errors.New(RegisteredNameError)
+	ErrReservedName   = errors.New(ReservedNameError)
+	ErrUnknownReader  = errors.New(UnknownReaderError)
+	ErrUnknownWriter  = errors.New(UnknownWriterError)
+)
+
+type (
+	// ReaderFactory returns the Reader of a registered input type, given
+	// the options it was asked for with, empty when there are none.
+	ReaderFactory func(options string) (Reader, error)
+
+	// WriterFactory returns the Writer of a registered output type, given
+	// the options it was asked for with, empty when there are none.
+	WriterFactory func(options string) (Writer, error)
+)
+
+// reservedNames are the input and output types built in, which a
+// registered stream would never be chosen over.
+var reservedNames = []string{"file", "custom", "random", "library"}
+
+var (
+	registryMutex   sync.RWMutex
+	readerFactories = make(map[string]ReaderFactory)
+	writerFactories = make(map[string]WriterFactory)
+)
+
+// RegisterReader makes the readers of factory available by name, usually
+// from the init of the package providing them so importing it is enough.
+func RegisterReader(name string, factory ReaderFactory) error {
+	if factory == nil {
+		return ErrNilFactory
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if err := checkName(name, readerFactories[name] != nil); err != nil {
+		return err
+	}
+	readerFactories[name] = factory
+	return nil
+}
+
+// RegisterWriter makes the writers of factory available by name, usually
+// from the init of the package providing them so importing it is enough.
+func RegisterWriter(name string, factory WriterFactory) error {
+	if factory == nil {
+		return ErrNilFactory
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if err := checkName(name, writerFactories[name] != nil); err != nil {
+		return err
+	}
+	writerFactories[name] = factory
+	return nil
+}
+
+func NewReader(name, options string) (Reader, error) {
+	registryMutex.RLock()
+	factory := readerFactories[name]
+	registryMutex.RUnlock()
+
+	if factory == nil {
+		return nil, ErrUnknownReader
+	}
+	return factory(options)
+}
+
+func NewWriter(name, options string) (Writer, error) {
+	registryMutex.RLock()
+	factory := writerFactories[name]
+	registryMutex.RUnlock()
+
+	if factory == nil {
+		return nil, ErrUnknownWriter
+	}
+	return factory(options)
+}
+
+// GetReaderNames returns the names of the registered readers sorted.
+func GetReaderNames() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	names := make([]string, 0, len(readerFactories))
+	for name := range readerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetWriterNames returns the names of the registered writers sorted.
+func GetWriterNames() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	names := make([]string, 0, len(writerFactories))
+	for name := range writerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkName(name string, isRegistered bool) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+	for _, reservedName := range reservedNames {
+		if name == reservedName {
+			return ErrReservedName
+		}
+	}
+	if isRegistered {
+		return ErrRegisteredName
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package io_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/stretchr/testify/assert"
+)
+
+type optionStream struct {
+	options string
+}
+
+func (stream *optionStream) Read() ([][]bool, error) {
+	return [][]bool{{stream.options != ""}}, nil
+}
+
+func (stream *optionStream) Write(generation [][]bool) error {
+	return nil
+}
+
+func newOptionReader(options string) (io.Reader, error) {
+	return &optionStream{options: options}, nil
+}
+
+func newOptionWriter(options string) (io.Writer, error) {
+	return &optionStream{options: options}, nil
+}
+
+func TestRegisterReader(t *testing.T) {
+	t.Run("should return error for empty name", func(t *testing.T) {
+		var expectedError = io.EmptyNameError
+
+		actualError := io.RegisterReader("", newOptionReader)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for nil factory", func(t *testing.T) {
+		var expectedError = io.NilFactoryError
+
+		actualError := io.RegisterReader("nil-reader", nil)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for name already registered", func(t *testing.T) {
+		var expectedError = io.RegisteredNameError
+		io.RegisterReader("twice-reader", newOptionReader)
+
+		actualError := io.RegisterReader("twice-reader", newOptionReader)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for reserved name", func(t *testing.T) {
+		var expectedError = io.ReservedNameError
+
+		for _, name := range []string{"file", "custom", "random", "library"} {
+			actualError := io.RegisterReader(name, newOptionReader)
+
+			assert.EqualError(t, actualError, expectedError)
+			assert.NotContains(t, io.GetReaderNames(), name)
+		}
+	})
+
+	t.Run("should make reader available by its name", func(t *testing.T) {
+		actualError := io.RegisterReader("listed-reader", newOptionReader)
+
+		assert.Nil(t, actualError)
+		assert.Contains(t, io.GetReaderNames(), "listed-reader")
+		assert.NotContains(t, io.GetWriterNames(), "listed-reader")
+	})
+}
+
+func TestRegisterWriter(t *testing.T) {
+	t.Run("should return error for nil factory", func(t *testing.T) {
+		var expectedError = io.NilFactoryError
+
+		actualError := io.RegisterWriter("nil-writer", nil)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for name already registered", func(t *testing.T) {
+		var expectedError = io.RegisteredNameError
+		io.RegisterWriter("twice-writer", newOptionWriter)
+
+		actualError := io.RegisterWriter("twice-writer", newOptionWriter)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error for reserved name", func(t *testing.T) {
+		var expectedError = io.ReservedNameError
+
+		for _, name := range []string{"file", "custom"} {
+			actualError := io.RegisterWriter(name, newOptionWriter)
+
+			assert.EqualError(t, actualError, expectedError)
+			assert.NotContains(t, io.GetWriterNames(), name)
+		}
+	})
+
+	t.Run("should make writer available by its name", func(t *testing.T) {
+		actualError := io.RegisterWriter("listed-writer", newOptionWriter)
+
+		assert.Nil(t, actualError)
+		assert.Contains(t, io.GetWriterNames(), "listed-writer")
+	})
+}
+
+func TestNewReader(t *testing.T) {
+	t.Run("should return nil and error for unknown name", func(t *testing.T) {
+		actualReader, actualError := io.NewReader("unknown-reader", "")
+
+		assert.Nil(t, actualReader)
+		assert.True(t, errors.Is(actualError, io.ErrUnknownReader))
+	})
+
+	t.Run("should return reader made with its options", func(t *testing.T) {
+		io.RegisterReader("option-reader", newOptionReader)
+		var expectedStream = &optionStream{options: "host=localhost,port=8080"}
+
+		actualReader, actualError := io.NewReader("option-reader", "host=localhost,port=8080")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedStream, actualReader)
+	})
+
+	t.Run("should return error of its factory", func(t *testing.T) {
+		var expectedError = errors.New("unreachable source")
+		io.RegisterReader("failing-reader", func(options string) (io.Reader, error) {
+			return nil, expectedError
+		})
+
+		actualReader, actualError := io.NewReader("failing-reader", "")
+
+		assert.Nil(t, actualReader)
+		assert.Equal(t, expectedError, actualError)
+	})
+}
+
+func TestNewWriter(t *testing.T) {
+	t.Run("should return nil and error for unknown name", func(t *testing.T) {
+		actualWriter, actualError := io.NewWriter("unknown-writer", "")
+
+		assert.Nil(t, actualWriter)
+		assert.True(t, errors.Is(actualError, io.ErrUnknownWriter))
+	})
+
+	t.Run("should return writer made with its options", func(t *testing.T) {
+		io.RegisterWriter("option-writer", newOptionWriter)
+		var expectedStream = &optionStream{options: "path=out"}
+
+		actualWriter, actualError := io.NewWriter("option-writer", "path=out")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedStream, actualWriter)
+	})
+}
+
+func TestGetReaderNames(t *testing.T) {
+	t.Run("should return names sorted", func(t *testing.T) {
+		io.RegisterReader("sorted-b", newOptionReader)
+		io.RegisterReader("sorted-a", newOptionReader)
+
+		actualNames := io.GetReaderNames()
+
+		assert.Contains(t, actualNames, "sorted-a")
+		assert.Contains(t, actualNames, "sorted-b")
+		assert.True(t, sort.StringsAreSorted(actualNames))
+	})
+}
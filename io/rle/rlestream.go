@@ -0,0 +1,380 @@
+package rle
+
+import (
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	FileExtension = ".rle"
+)
+
+const (
+	PathEmptyError        = "path passed is empty"
+	NilFileSystemError    = "file system passed is nil"
+	InvalidExtensionError = "invalid file extension (file should be *.rle)"
+	NotFoundFileError     = "file is not found"
+	EmptyFileError        = "file is empty"
+	InvalidHeaderError    = "header is invalid (use: x = [width], y = [height], rule = [rule])"
+	InvalidFormatError    = "format is invalid ('b' or '.': dead, 'o' or 'A': alive, 'B'-'X' and 'p'-'y' with 'A'-'X': other states, '$': end of row, '!': end)"
+	InvalidStateError     = "state is beyond the living state (use ReadStates with a rule of more states)"
+)
+
+var (
+	ErrPathEmpty        = errors.New(PathEmptyError)
+	ErrNilFileSystem    = errors.New(NilFileSystemError)
+	ErrInvalidExtension = errors.New(InvalidExtensionError)
+	ErrNotFoundFile     = errors.New(NotFoundFileError)
+	ErrEmptyFile        = errors.New(EmptyFileError)
+	ErrInvalidHeader    = errors.New(InvalidHeaderError)
+	ErrInvalidFormat    = errors.New(InvalidFormatError)
+	ErrInvalidState     = errors.New(InvalidStateError)
+)
+
+const (
+	commentPrefix   = "#"
+	headerSeparator = ","
+	valueSeparator  = "="
+	widthKey        = "x"
+	heightKey       = "y"
+	ruleKey         = "rule"
+
+	rowEnd     = '$'
+	patternEnd = '!'
+
+	// states above 24 are written with a prefix from 'p' on, each prefix
+	// adding 24 to the letter after it
+	statesPerPrefix = 24
+	minPrefix       = 'p'
+	maxPrefix       = 'y'
+	minLetter       = 'A'
+	maxLetter       = 'X'
+)
+
+// RLEStream reads a pattern in run length encoding, as written by Golly:
+// a header with the size and the rule, then runs of a state, each an
+// optional count followed by the character of the state.
+type RLEStream struct {
+	path       string
+	fileSystem fs.FS
+}
+
+func (rleStream *RLEStream) Read() ([][]bool, error) {
+	states, errs := rleStream.parse(rule.Alive)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	outputGeneration := make([][]bool, len(states))
+	for i := 0; i < len(states); i++ {
+		outputGeneration[i] = make([]bool, len(states[i]))
+		for j := 0; j < len(states[i]); j++ {
+			outputGeneration[i][j] = states[i][j] == rule.Alive
+		}
+	}
+
+	return outputGeneration, nil
+}
+
+// ReadStates reads the state of each cell within the size of the header.
+func (rleStream *RLEStream) ReadStates() ([][]uint8, error) {
+	states, errs := rleStream.parse(rule.MaxNumOfTableStates - 1)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return states, nil
+}
+
+// Validate returns every problem of the file read with a rule of
+// numOfStates, nil when there is none.
+func (rleStream *RLEStream) Validate(numOfStates int) []error {
+	if numOfStates < rule.MinNumOfStates {
+		numOfStates = rule.MinNumOfStates
+	}
+	if numOfStates > rule.MaxNumOfTableStates {
+		numOfStates = rule.MaxNumOfTableStates
+	}
+
+	_, errs := rleStream.parse(uint8(numOfStates - 1))
+	return errs
+}
+
+// GetRule returns the rule given in the header, empty when there is none.
+func (rleStream *RLEStream) GetRule() (string, error) {
+	lines, err := rleStream.readLines()
+	if err != nil {
+		return "", err
+	}
+
+	_, _, cellRule, err := rleStream.parseHeaderLine(lines[0])
+	return cellRule, err
+}
+
+// parse returns the state of each cell, none above maxState, or every
+// problem found in the file.
+func (rleStream *RLEStream) parse(maxState uint8) ([][]uint8, []error) {
+	lines, err := rleStream.readLines()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	width, height, _, err := rleStream.parseHeaderLine(lines[0])
+	if err != nil {
+		return nil, []error{err}
+	}
+	states := make([][]uint8, height)
+	for i := 0; i < height; i++ {
+		states[i] = make([]uint8, width)
+	}
+
+	// the body is read as one, so a run may go on over the end of a line,
+	// while the position of each character is kept for the errors
+	var body strings.Builder
+	positions := make([]line, 0)
+	for _, bodyLine := range lines[1:] {
+		body.WriteString(bodyLine.text)
+		for i := 0; i < len(bodyLine.text); i++ {
+			positions = append(positions, line{number: bodyLine.number, column: bodyLine.column + i})
+		}
+	}
+
+	text := body.String()
+	errs := make([]error, 0)
+	expected := expectedCharacters(maxState)
+	report := func(i int, expected string, problem error) {
+		errs = append(errs, &io.ParseError{
+			Path:     rleStream.path,
+			Line:     positions[i].number,
+			Column:   positions[i].column,
+			Found:    text[i],
+			Expected: expected,
+			Err:      problem,
+		})
+	}
+
+	row, col, count := 0, 0, 0
+	for i := 0; i < len(text); i++ {
+		character := text[i]
+		switch {
+		case character >= '0' && character <= '9':
+			count = count*10 + int(character-'0')
+			continue
+		case character == ' ' || character == '\t':
+			continue
+		case character == rowEnd:
+			row += runLength(count)
+			col = 0
+		case character == patternEnd:
+			i = len(text)
+		default:
+			state, length, isValid := parseState(text[i:])
+			if !isValid {
+				report(i, expected, ErrInvalidFormat)
+				break
+			}
+
+			run := runLength(count)
+			switch {
+			case state > maxState:
+				report(i, expected, ErrInvalidState)
+			case row >= height:
+				report(i, string(patternEnd), ErrInvalidFormat)
+			case col+run > width:
+				report(i, string([]byte{rowEnd, patternEnd}), ErrInvalidFormat)
+			default:
+				for j := 0; j < run; j++ {
+					states[row][col+j] = state
+				}
+			}
+			col += run
+			i += length - 1
+		}
+		count = 0
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return states, nil
+}
+
+// line is a line of the file with its number and the column of its first
+// character, both counted from 1.
+type line struct {
+	number int
+	column int
+	text   string
+}
+
+// readLines returns the header and the lines after it, without comments.
+func (rleStream *RLEStream) readLines() ([]line, error) {
+	var content []byte
+	var err error
+	if rleStream.fileSystem != nil {
+		content, err = fs.ReadFile(rleStream.fileSystem, rleStream.path)
+	} else {
+		content, err = ioutil.ReadFile(rleStream.path)
+	}
+	if os.IsNotExist(err) {
+		return nil, &io.FileError{Path: rleStream.path, Err: ErrNotFoundFile, Cause: err}
+	}
+	if err != nil {
+		return nil, &io.FileError{Path: rleStream.path, Err: io.ErrUnreadableFile, Cause: err}
+	}
+
+	lines := make([]line, 0)
+	for i, text := range strings.Split(string(content), "\n") {
+		trimmedText := strings.TrimSpace(text)
+		if trimmedText == "" || strings.HasPrefix(trimmedText, commentPrefix) {
+			continue
+		}
+		lines = append(lines, line{
+			number: i + 1,
+			column: strings.Index(text, trimmedText) + 1,
+			text:   trimmedText,
+		})
+	}
+	if len(lines) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	return lines, nil
+}
+
+// parseHeaderLine is parseHeader telling the position of the header when
+// it is invalid.
+func (rleStream *RLEStream) parseHeaderLine(header line) (int, int, string, error) {
+	width, height, cellRule, err := parseHeader(header.text)
+	if err != nil {
+		return 0, 0, "", &io.ParseError{
+			Path:   rleStream.path,
+			Line:   header.number,
+			Column: header.column,
+			Err:    ErrInvalidHeader,
+		}
+	}
+	return width, height, cellRule, nil
+}
+
+func parseHeader(header string) (int, int, string, error) {
+	var width, height int
+	var cellRule string
+	fields := strings.Split(header, headerSeparator)
+	for i, field := range fields {
+		parts := strings.SplitN(field, valueSeparator, 2)
+		if len(parts) != 2 {
+			return 0, 0, "", ErrInvalidHeader
+		}
+
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		var err error
+		switch key {
+		case widthKey:
+			width, err = strconv.Atoi(value)
+		case heightKey:
+			height, err = strconv.Atoi(value)
+		case ruleKey:
+			// the rule is last and may hold separators itself, e.g.
+			// R5,C0,M1,S34..58,B34..45,NM
+			cellRule = strings.TrimSpace(strings.Join(append([]string{value}, fields[i+1:]...), headerSeparator))
+		}
+		if err != nil {
+			return 0, 0, "", ErrInvalidHeader
+		}
+		if key == ruleKey {
+			break
+		}
+	}
+	if width < 1 || height < 1 {
+		return 0, 0, "", ErrInvalidHeader
+	}
+
+	return width, height, cellRule, nil
+}
+
+// parseState reads the state at the start of text and the number of
+// characters it takes.
+func parseState(text string) (uint8, int, bool) {
+	character := text[0]
+	switch {
+	case character == 'b' || character == '.':
+		return rule.Dead, 1, true
+	case character == 'o':
+		return rule.Alive, 1, true
+	case character >= minLetter && character <= maxLetter:
+		return checkState(int(character-minLetter) + 1)
+	case character >= minPrefix && character <= maxPrefix:
+		if len(text) < 2 || text[1] < minLetter || text[1] > maxLetter {
+			return 0, 0, false
+		}
+		state, _, isValid := checkState(int(character-minPrefix+1)*statesPerPrefix + int(text[1]-minLetter) + 1)
+		return state, 2, isValid
+	default:
+		return 0, 0, false
+	}
+}
+
+func checkState(state int) (uint8, int, bool) {
+	if state >= rule.MaxNumOfTableStates {
+		return 0, 0, false
+	}
+	return uint8(state), 1, true
+}
+
+// expectedCharacters returns the characters of a body with states up to
+// maxState.
+func expectedCharacters(maxState uint8) string {
+	characters := []byte("0123456789bo.$!")
+	for state := 1; state <= int(maxState) && state <= statesPerPrefix; state++ {
+		characters = append(characters, byte(minLetter+state-1))
+	}
+	for prefix := 1; prefix*statesPerPrefix < int(maxState); prefix++ {
+		characters = append(characters, byte(minPrefix+prefix-1))
+	}
+	return string(characters)
+}
+
+func runLength(count int) int {
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+func New(path string) (*RLEStream, error) {
+	if path == "" {
+		return nil, ErrPathEmpty
+	}
+	if filepath.Ext(path) != FileExtension {
+		return nil, ErrInvalidExtension
+	}
+
+	var rleStream = RLEStream{
+		path: path,
+	}
+	return &rleStream, nil
+}
+
+// NewFS returns the stream reading path from fileSystem instead of the
+// disk, e.g. a pattern embedded in the binary.
+func NewFS(fileSystem fs.FS, path string) (*RLEStream, error) {
+	if fileSystem == nil {
+		return nil, ErrNilFileSystem
+	}
+
+	rleStream, err := New(path)
+	if err != nil {
+		return nil, err
+	}
+	rleStream.fileSystem = fileSystem
+	return rleStream, nil
+}
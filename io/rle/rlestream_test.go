@@ -0,0 +1,267 @@
+package rle_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/rle"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	directory, err := ioutil.TempDir("", "rle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(directory, name)
+	if err = ioutil.WriteFile(path, []byte(content), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for empty path", func(t *testing.T) {
+		var expectedError = rle.PathEmptyError
+
+		actualStream, actualError := rle.New("")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid extension", func(t *testing.T) {
+		var expectedError = rle.InvalidExtensionError
+
+		actualStream, actualError := rle.New("./glider.cell")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, expectedError)
+	})
+}
+
+func TestNewFS(t *testing.T) {
+	t.Run("should return nil and error for nil file system", func(t *testing.T) {
+		var expectedError = rle.NilFileSystemError
+
+		actualStream, actualError := rle.NewFS(nil, "glider.rle")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid extension", func(t *testing.T) {
+		var expectedError = rle.InvalidExtensionError
+
+		actualStream, actualError := rle.NewFS(fstest.MapFS{}, "glider.cell")
+
+		assert.Nil(t, actualStream)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should read pattern from file system", func(t *testing.T) {
+		fileSystem := fstest.MapFS{
+			"patterns/blinker.rle": {Data: []byte("x = 3, y = 1\n3o!\n")},
+		}
+		rleStream, _ := rle.NewFS(fileSystem, "patterns/blinker.rle")
+
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, [][]bool{{true, true, true}}, actualGeneration)
+	})
+
+	t.Run("should return error for file not in file system", func(t *testing.T) {
+		var expectedError = "patterns/blinker.rle: " + rle.NotFoundFileError + ": file does not exist"
+		rleStream, _ := rle.NewFS(fstest.MapFS{}, "patterns/blinker.rle")
+
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, actualGeneration)
+		assert.EqualError(t, actualError, expectedError)
+	})
+}
+
+func TestRead(t *testing.T) {
+	t.Run("should read glider", func(t *testing.T) {
+		path := writeFile(t, "glider.rle", "#N Glider\nx = 3, y = 3, rule = B3/S23\nbob$2bo$3o!\n")
+		rleStream, _ := rle.New(path)
+		expectedGeneration := [][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		}
+
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedGeneration, actualGeneration)
+	})
+
+	t.Run("should read runs over lines and empty rows", func(t *testing.T) {
+		path := writeFile(t, "rows.rle", "x = 4, y = 4\n2o$\n2$3bo!\n")
+		rleStream, _ := rle.New(path)
+		expectedGeneration := [][]bool{
+			{true, true, false, false},
+			{false, false, false, false},
+			{false, false, false, false},
+			{false, false, false, true},
+		}
+
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedGeneration, actualGeneration)
+	})
+
+	t.Run("should return nil and error for states beyond alive", func(t *testing.T) {
+		path := writeFile(t, "states.rle", "x = 2, y = 1, rule = WireWorld\nAB!\n")
+		rleStream, _ := rle.New(path)
+		var expectedError = &io.ParseError{
+			Path:     path,
+			Line:     2,
+			Column:   2,
+			Found:    'B',
+			Expected: "0123456789bo.$!A",
+			Err:      rle.ErrInvalidState,
+		}
+
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, actualGeneration)
+		assert.Equal(t, expectedError, actualError)
+	})
+
+	t.Run("should return nil and error for missing file", func(t *testing.T) {
+		var expectedError = "./missing.rle: " + rle.NotFoundFileError + ": no such file or directory"
+		rleStream, _ := rle.New("./missing.rle")
+
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, actualGeneration)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for empty file", func(t *testing.T) {
+		var expectedError = rle.EmptyFileError
+		path := writeFile(t, "empty.rle", "#C nothing\n\n")
+		rleStream, _ := rle.New(path)
+
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, actualGeneration)
+		assert.EqualError(t, actualError, expectedError)
+	})
+}
+
+func TestReadStates(t *testing.T) {
+	t.Run("should read multi-state cells", func(t *testing.T) {
+		path := writeFile(t, "wire.rle", "x = 5, y = 2, rule = WireWorld\nBA3C$.pA!\n")
+		rleStream, _ := rle.New(path)
+		expectedStates := [][]uint8{
+			{2, 1, 3, 3, 3},
+			{0, 25, 0, 0, 0},
+		}
+
+		actualStates, actualError := rleStream.ReadStates()
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedStates, actualStates)
+	})
+
+	t.Run("should return nil and error for invalid header", func(t *testing.T) {
+		var expectedError = rle.ErrInvalidHeader
+		headers := []string{"x = 3\nooo!", "x = a, y = 1\nooo!", "bob$2bo$3o!"}
+
+		for _, header := range headers {
+			rleStream, _ := rle.New(writeFile(t, "header.rle", header))
+
+			actualStates, actualError := rleStream.ReadStates()
+
+			var parseError *io.ParseError
+			assert.Nil(t, actualStates, header)
+			assert.True(t, errors.As(actualError, &parseError), header)
+			assert.True(t, errors.Is(actualError, expectedError), header)
+		}
+	})
+
+	t.Run("should return nil and error for invalid format", func(t *testing.T) {
+		var expectedError = rle.ErrInvalidFormat
+		bodies := []string{"x = 3, y = 1\n4o!", "x = 3, y = 1\nozo!", "x = 3, y = 1\no$o!", "x = 3, y = 1\npo!", "x = 3, y = 1\nyX!"}
+
+		for _, body := range bodies {
+			rleStream, _ := rle.New(writeFile(t, "body.rle", body))
+
+			actualStates, actualError := rleStream.ReadStates()
+
+			var parseError *io.ParseError
+			assert.Nil(t, actualStates, body)
+			assert.True(t, errors.As(actualError, &parseError), body)
+			assert.True(t, errors.Is(actualError, expectedError), body)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should return nil for valid file", func(t *testing.T) {
+		path := writeFile(t, "glider.rle", "x = 3, y = 3\nbo$2bo$3o!\n")
+		rleStream, _ := rle.New(path)
+
+		actualErrors := rleStream.Validate(2)
+
+		assert.Nil(t, actualErrors)
+	})
+
+	t.Run("should return error at the position of invalid header", func(t *testing.T) {
+		path := writeFile(t, "header.rle", "#N Header\n  x = a, y = 1\nooo!\n")
+		rleStream, _ := rle.New(path)
+		var expectedErrors = []error{
+			&io.ParseError{Path: path, Line: 2, Column: 3, Err: rle.ErrInvalidHeader},
+		}
+
+		actualErrors := rleStream.Validate(2)
+
+		assert.Equal(t, expectedErrors, actualErrors)
+		assert.EqualError(t, actualErrors[0], path+":2:3: "+rle.InvalidHeaderError)
+	})
+
+	t.Run("should return every problem of the body over its lines", func(t *testing.T) {
+		path := writeFile(t, "body.rle", "x = 3, y = 2\r\n2oz$\r\n 4o$\r\nB!\r\n")
+		rleStream, _ := rle.New(path)
+		var expectedErrors = []error{
+			&io.ParseError{Path: path, Line: 2, Column: 3, Found: 'z', Expected: "0123456789bo.$!A", Err: rle.ErrInvalidFormat},
+			&io.ParseError{Path: path, Line: 3, Column: 3, Found: 'o', Expected: "$!", Err: rle.ErrInvalidFormat},
+			&io.ParseError{Path: path, Line: 4, Column: 1, Found: 'B', Expected: "0123456789bo.$!A", Err: rle.ErrInvalidState},
+		}
+
+		actualErrors := rleStream.Validate(2)
+
+		assert.Equal(t, expectedErrors, actualErrors)
+	})
+
+	t.Run("should accept the states of the rule", func(t *testing.T) {
+		path := writeFile(t, "wire.rle", "x = 3, y = 1, rule = WireWorld\nABC!\n")
+		rleStream, _ := rle.New(path)
+
+		actualErrors := rleStream.Validate(4)
+
+		assert.Nil(t, actualErrors)
+	})
+}
+
+func TestGetRule(t *testing.T) {
+	t.Run("should return rule of header with its separators", func(t *testing.T) {
+		path := writeFile(t, "bosco.rle", "x = 1, y = 1, rule = R5,C0,M1,S34..58,B34..45,NM\no!\n")
+		rleStream, _ := rle.New(path)
+
+		actualRule, actualError := rleStream.GetRule()
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, "R5,C0,M1,S34..58,B34..45,NM", actualRule)
+	})
+}
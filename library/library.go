@@ -0,0 +1,127 @@
+package library
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/irainia/gameoflife-go/io/rle"
+)
+
+const (
+	UnknownPatternError = "unknown pattern (use the patterns command to list the names)"
+)
+
+var (
+	ErrUnknownPattern = errors.New(UnknownPatternError)
+)
+
+const (
+	patternDirectory = "patterns"
+
+	titlePrefix        = "#N"
+	commentPrefix      = "#C"
+	lowerCommentPrefix = "#c"
+)
+
+//go:embed patterns
+var patternFiles embed.FS
+
+// Pattern is a well-known pattern embedded in the binary, read by its name
+// as a run length encoded file with its title and description in comments.
+type Pattern struct {
+	name        string
+	title       string
+	description string
+	rule        string
+	width       int
+	height      int
+}
+
+func (pattern *Pattern) GetName() string {
+	return pattern.name
+}
+
+func (pattern *Pattern) GetTitle() string {
+	return pattern.title
+}
+
+func (pattern *Pattern) GetDescription() string {
+	return pattern.description
+}
+
+// GetRule returns the rule the pattern is meant for, empty when any rule
+// would do.
+func (pattern *Pattern) GetRule() string {
+	return pattern.rule
+}
+
+func (pattern *Pattern) GetWidth() int {
+	return pattern.width
+}
+
+func (pattern *Pattern) GetHeight() int {
+	return pattern.height
+}
+
+// GetReader returns the stream reading the pattern like any other run
+// length encoded file.
+func (pattern *Pattern) GetReader() (*rle.RLEStream, error) {
+	return rle.NewFS(patternFiles, getPath(pattern.name))
+}
+
+func Get(name string) (*Pattern, error) {
+	content, err := fs.ReadFile(patternFiles, getPath(name))
+	if err != nil {
+		return nil, ErrUnknownPattern
+	}
+
+	var pattern = Pattern{
+		name: name,
+	}
+	descriptions := make([]string, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, titlePrefix):
+			pattern.title = strings.TrimSpace(line[len(titlePrefix):])
+		case strings.HasPrefix(line, commentPrefix), strings.HasPrefix(line, lowerCommentPrefix):
+			descriptions = append(descriptions, strings.TrimSpace(line[len(commentPrefix):]))
+		}
+	}
+	pattern.description = strings.Join(descriptions, " ")
+
+	rleStream, err := pattern.GetReader()
+	if err != nil {
+		return nil, err
+	}
+	states, err := rleStream.ReadStates()
+	if err != nil {
+		return nil, err
+	}
+	pattern.height, pattern.width = len(states), len(states[0])
+	pattern.rule, err = rleStream.GetRule()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pattern, nil
+}
+
+// GetNames returns the names of the patterns in the library, sorted.
+func GetNames() []string {
+	// the directory is embedded, so reading it cannot fail
+	entries, _ := fs.ReadDir(patternFiles, patternDirectory)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), rle.FileExtension))
+	}
+
+	return names
+}
+
+func getPath(name string) string {
+	return path.Join(patternDirectory, name+rle.FileExtension)
+}
@@ -0,0 +1,116 @@
+package library_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/apgcode"
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/library"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("should return nil and error for unknown pattern", func(t *testing.T) {
+		var expectedError = library.UnknownPatternError
+
+		actualPattern, actualError := library.Get("unknown")
+
+		assert.Nil(t, actualPattern)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for path outside the library", func(t *testing.T) {
+		var expectedError = library.UnknownPatternError
+
+		actualPattern, actualError := library.Get("../library")
+
+		assert.Nil(t, actualPattern)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return pattern with its metadata", func(t *testing.T) {
+		actualPattern, actualError := library.Get("gosper-gun")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, "gosper-gun", actualPattern.GetName())
+		assert.Equal(t, "Gosper glider gun", actualPattern.GetTitle())
+		assert.Contains(t, actualPattern.GetDescription(), "every 30 generations")
+		assert.Equal(t, "B3/S23", actualPattern.GetRule())
+		assert.Equal(t, 36, actualPattern.GetWidth())
+		assert.Equal(t, 9, actualPattern.GetHeight())
+	})
+
+	t.Run("should read pattern through its reader", func(t *testing.T) {
+		pattern, _ := library.Get("glider")
+		expectedGeneration := [][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		}
+
+		rleStream, streamError := pattern.GetReader()
+		actualGeneration, actualError := rleStream.Read()
+
+		assert.Nil(t, streamError)
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedGeneration, actualGeneration)
+	})
+
+	t.Run("should return patterns behaving as described", func(t *testing.T) {
+		var expectedPeriods = map[string]int{
+			"block":          1,
+			"eater":          1,
+			"blinker":        2,
+			"pulsar":         3,
+			"pentadecathlon": 15,
+			"glider":         4,
+			"lwss":           4,
+			"mwss":           4,
+			"hwss":           4,
+		}
+
+		for name, expectedPeriod := range expectedPeriods {
+			pattern, _ := library.Get(name)
+			rleStream, _ := pattern.GetReader()
+			generation, _ := rleStream.Read()
+			cellState, _ := cell.New(generation)
+
+			actualPeriod, _, _, actualError := apgcode.Classify(cellState, expectedPeriod)
+
+			assert.Nil(t, actualError, name)
+			assert.Equal(t, expectedPeriod, actualPeriod, name)
+		}
+	})
+
+	t.Run("should return diehard dying after 130 generations", func(t *testing.T) {
+		pattern, _ := library.Get("diehard")
+		rleStream, _ := pattern.GetReader()
+		generation, _ := rleStream.Read()
+		cellState, _ := cell.New(generation)
+
+		for i := 0; i < 129; i++ {
+			cellState = cellState.GetNextState()
+		}
+
+		assert.NotEmpty(t, cellState.GetGeneration())
+		assert.Empty(t, cellState.GetNextState().GetGeneration())
+	})
+}
+
+func TestGetNames(t *testing.T) {
+	t.Run("should return sorted names of every readable pattern", func(t *testing.T) {
+		actualNames := library.GetNames()
+
+		assert.Contains(t, actualNames, "glider")
+		assert.Contains(t, actualNames, "r-pentomino")
+		assert.Contains(t, actualNames, "acorn")
+		assert.True(t, sort.StringsAreSorted(actualNames))
+		for _, name := range actualNames {
+			pattern, err := library.Get(name)
+			assert.Nil(t, err, name)
+			assert.NotEmpty(t, pattern.GetTitle(), name)
+			assert.NotEmpty(t, pattern.GetDescription(), name)
+		}
+	})
+}
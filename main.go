@@ -1,43 +1,439 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/census"
+	"github.com/irainia/gameoflife-go/checkpoint"
+	"github.com/irainia/gameoflife-go/diff"
+	gameio "github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/random"
 	"github.com/irainia/gameoflife-go/param"
+	"github.com/irainia/gameoflife-go/predecessor"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/irainia/gameoflife-go/server"
+	"github.com/irainia/gameoflife-go/simulation"
+	"github.com/irainia/gameoflife-go/transform"
+)
+
+const (
+	serveCommand       = "serve"
+	searchCommand      = "search"
+	predecessorCommand = "predecessor"
+	diffCommand        = "diff"
+	patternsCommand    = "patterns"
+	validateCommand    = "validate"
 )
 
 func main() {
 	args := os.Args
 
-	parameter, err := param.New(args[1:], nil, nil)
+	if len(args) > 1 {
+		switch args[1] {
+		case serveCommand:
+			serve(args[2:])
+			return
+		case searchCommand:
+			search(args[2:])
+			return
+		case predecessorCommand:
+			findPredecessors(args[2:])
+			return
+		case diffCommand:
+			compareGenerations(args[2:])
+			return
+		case patternsCommand:
+			listPatterns(args[2:])
+			return
+		case validateCommand:
+			validate(args[2:])
+			return
+		}
+	}
+
+	run(args[1:])
+}
+
+func run(args []string) {
+	parameter, err := param.New(args, nil, nil)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	gameSimulation, err := newSimulation(parameter)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	gameSimulation.OnGeneration(printGeneration)
+	statsRecorder := parameter.GetStatsRecorder()
+	if statsRecorder != nil {
+		if parameter.GetResumeCheckpoint() != nil {
+			statsRecorder.Resume(gameSimulation.GetGeneration(), gameSimulation.GetCellState())
+		} else {
+			statsRecorder.Record(gameSimulation.GetGeneration(), gameSimulation.GetCellState())
+		}
+		gameSimulation.OnGeneration(statsRecorder.Record)
+	}
+	if parameter.GetCheckpointEvery() > 0 {
+		gameSimulation.OnGeneration(func(generation int, cellState *cell.CellState) {
+			if generation%parameter.GetCheckpointEvery() == 0 {
+				saveCheckpoint(parameter, generation, cellState)
+			}
+		})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if parameter.GetTimeout() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, parameter.GetTimeout())
+		defer cancel()
+	}
+
+	printGeneration(gameSimulation.GetGeneration(), gameSimulation.GetCellState())
+	numOfSteps := parameter.GetNumOfGeneration() - gameSimulation.GetGeneration()
+	cellState, runErr := gameSimulation.StepNContext(ctx, numOfSteps)
+	if cellState == nil {
+		log.Fatalln(runErr)
+	}
+	if runErr != nil && parameter.GetCheckpointEvery() > 0 {
+		saveCheckpoint(parameter, gameSimulation.GetGeneration(), cellState)
+	}
+	if statsRecorder != nil {
+		if err = statsRecorder.Close(); err != nil {
+			log.Printf("writing stats to %s failed: %v\n", statsRecorder.GetPath(), err)
+		}
+	}
+
+	err = writeOutput(parameter, cellState)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if runErr != nil {
+		log.Fatalf("run stopped at generation %d of %d: %v\n", gameSimulation.GetGeneration(), parameter.GetNumOfGeneration(), runErr)
+	}
+}
+
+func newSimulation(parameter *param.Param) (*simulation.Simulation, error) {
+	resumeCheckpoint := parameter.GetResumeCheckpoint()
+	if resumeCheckpoint != nil {
+		return simulation.NewFromGeneration(track(resumeCheckpoint.GetCellState(), parameter.GetRender()), resumeCheckpoint.GetGeneration())
+	}
+
 	reader := parameter.GetReader()
-	initialGeneration, err := reader.Read()
+	if soupStream, isSoup := reader.(*random.SoupStream); isSoup {
+		log.Printf("random soup %dx%d with symmetry %s and seed %d\n",
+			soupStream.GetWidth(), soupStream.GetHeight(), soupStream.GetSymmetry(), soupStream.GetSeed())
+	}
+
+	cellState, err := readCellState(reader, parameter.GetRule(), parameter.GetTransform())
+	if err != nil {
+		return nil, err
+	}
+
+	return simulation.New(track(cellState, parameter.GetRender()))
+}
+
+// track starts tracking what is rendered at the end of the run.
+func track(cellState *cell.CellState, render string) *cell.CellState {
+	switch render {
+	case param.RenderAge:
+		return cellState.WithAges()
+	case param.RenderHeat:
+		return cellState.WithHeatMap()
+	default:
+		return cellState
+	}
+}
+
+// readCellState reads the states of a multi-state rule when reader can
+// carry them, and the living cells otherwise. What is read is made into a
+// cell state before it is transformed, so a generation that is not a
+// rectangle is reported instead of being transformed.
+func readCellState(reader gameio.Reader, cellRule *rule.Rule, inputTransform *transform.Transform) (*cell.CellState, error) {
+	var cellState *cell.CellState
+	stateReader, isStateReader := reader.(gameio.StateReader)
+	if !isStateReader || cellRule.GetNumOfStates() == rule.MinNumOfStates {
+		initialGeneration, err := reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		cellState, err = cell.NewWithRule(initialGeneration, cellRule)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		initialStates, err := stateReader.ReadStates()
+		if err != nil {
+			return nil, err
+		}
+		cellState, err = cell.NewWithStates(initialStates, cellRule, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	states := cellState.GetStates()
+	if len(states) == 0 {
+		return cellState, nil
+	}
+	return cell.NewWithStates(inputTransform.ApplyToStates(states), cellRule, 0, 0)
+}
+
+// writeOutput writes the ages or the heat map of cellState when they are
+// rendered, and cellState itself otherwise.
+func writeOutput(parameter *param.Param, cellState *cell.CellState) error {
+	heatWriter, isHeatWriter := parameter.GetWriter().(gameio.HeatWriter)
+	switch {
+	case isHeatWriter && parameter.GetRender() == param.RenderAge:
+		return heatWriter.WriteHeat(cellState.GetAges())
+	case isHeatWriter && parameter.GetRender() == param.RenderHeat:
+		heat, _, _ := cellState.GetHeatMap()
+		return heatWriter.WriteHeat(heat)
+	default:
+		return writeCellState(parameter.GetWriter(), cellState)
+	}
+}
+
+func writeCellState(writer gameio.Writer, cellState *cell.CellState) error {
+	stateWriter, isStateWriter := writer.(gameio.StateWriter)
+	if !isStateWriter || cellState.GetRule().GetNumOfStates() == rule.MinNumOfStates {
+		return writer.Write(cellState.GetGeneration())
+	}
+
+	return stateWriter.WriteStates(cellState.GetStates())
+}
+
+func saveCheckpoint(parameter *param.Param, generation int, cellState *cell.CellState) {
+	runCheckpoint, err := checkpoint.New(generation, parameter.GetNumOfGeneration(), cellState)
+	if err == nil {
+		_, err = runCheckpoint.Save(parameter.GetCheckpointDir())
+	}
+	if err != nil {
+		log.Printf("checkpoint at generation %d failed: %v\n", generation, err)
+	}
+}
+
+func printGeneration(generation int, cellState *cell.CellState) {
+	fmt.Println()
+	fmt.Printf("generation %d\n", generation)
+	fmt.Println(cellState)
+}
+
+func serve(args []string) {
+	parameter, err := param.NewServe(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	webServer, err := server.New(parameter.GetAddress())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("serving on http://%s\n", webServer.GetAddress())
+	log.Fatal(webServer.ListenAndServe())
+}
+
+func search(args []string) {
+	mode, err := param.GetSearchMode(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if mode == param.ShipSearchMode {
+		searchShips(args)
+		return
+	}
+
+	parameter, err := param.NewSearch(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	soupCensus, err := census.New(parameter.GetSoupStream(), parameter.GetRule(), parameter.GetMaxGeneration(), parameter.GetMaxPeriod())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	soupStream := parameter.GetSoupStream()
+	log.Printf("searching %d soups of %dx%d with symmetry %s from seed %d\n",
+		parameter.GetNumOfSoups(), soupStream.GetWidth(), soupStream.GetHeight(), soupStream.GetSymmetry(), soupStream.GetSeed())
+	searchErr := soupCensus.Search(ctx, parameter.GetNumOfSoups())
+
+	report := soupCensus.GetReport()
+	if err = report.Write(parameter.GetReportPath()); err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("%d objects from %d soups written to %s\n", report.NumOfObjects, report.NumOfSoups, parameter.GetReportPath())
+	if searchErr != nil {
+		log.Fatalf("search stopped after %d of %d soups: %v\n", report.NumOfSoups, parameter.GetNumOfSoups(), searchErr)
+	}
+}
+
+func searchShips(args []string) {
+	parameter, err := param.NewShipSearch(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if parameter.GetTimeout() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, parameter.GetTimeout())
+		defer cancel()
+	}
+
+	finder := parameter.GetFinder()
+	log.Printf("searching period %d moving %d rows up within %d columns and %d rows under %s\n",
+		finder.GetPeriod(), finder.GetShift(), finder.GetWidth(), finder.GetMaxHeight(), finder.GetRule())
+	results, findErr := finder.Find(ctx, parameter.GetMaxResults())
+	if findErr == nil && len(results) == 0 {
+		log.Printf("no pattern of period %d moving %d rows up within %d columns and %d rows\n",
+			finder.GetPeriod(), finder.GetShift(), finder.GetWidth(), finder.GetMaxHeight())
+		return
+	}
+
+	writers := parameter.GetWriters()
+	for i, found := range results {
+		fmt.Println()
+		fmt.Printf("result %d\n", i+1)
+		fmt.Println(found)
+		if err = writers[i].Write(found.GetGeneration()); err != nil {
+			log.Fatalln(err)
+		}
+	}
+	log.Printf("%d patterns found\n", len(results))
+	if findErr != nil {
+		log.Fatalf("ship search stopped: %v\n", findErr)
+	}
+}
+
+func findPredecessors(args []string) {
+	parameter, err := param.NewPredecessor(args)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	cellState, err := cell.New(initialGeneration)
+	generation, err := parameter.GetReader().Read()
 	if err != nil {
 		log.Fatalln(err)
 	}
+	cellState, err := cell.NewWithRule(generation, parameter.GetRule())
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if parameter.GetTimeout() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, parameter.GetTimeout())
+		defer cancel()
+	}
 
-	for i := 0; i <= parameter.GetNumOfGeneration(); i++ {
+	predecessors, findErr := predecessor.Find(ctx, cellState, parameter.GetMargin(), parameter.GetMaxResults())
+	if findErr == nil && len(predecessors) == 0 {
+		log.Printf("no predecessor within %d cells of the pattern, it is a Garden of Eden within that box\n", parameter.GetMargin())
+		return
+	}
+
+	writers := parameter.GetWriters()
+	for i, found := range predecessors {
 		fmt.Println()
-		fmt.Printf("genereation %d\n", i)
-		fmt.Println(cellState)
-		cellState = cellState.GetNextState()
+		fmt.Printf("predecessor %d\n", i+1)
+		fmt.Println(found)
+		if err = writers[i].Write(found.GetGeneration()); err != nil {
+			log.Fatalln(err)
+		}
 	}
+	log.Printf("%d predecessors found within %d cells of the pattern\n", len(predecessors), parameter.GetMargin())
+	if findErr != nil {
+		log.Fatalf("predecessor search stopped: %v\n", findErr)
+	}
+}
 
-	writer := parameter.GetWriter()
-	err = writer.Write(cellState.GetGeneration())
+func compareGenerations(args []string) {
+	parameter, err := param.NewDiff(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	identity, err := transform.Get(transform.Identity)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	before, err := readCellState(parameter.GetBeforeReader(), parameter.GetRule(), identity)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	after, err := readCellState(parameter.GetAfterReader(), parameter.GetRule(), identity)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	for i := 0; i < parameter.GetNumOfGeneration(); i++ {
+		after = after.GetNextState()
+	}
+
+	generationDiff, err := diff.Compare(before, after, parameter.IsTranslated())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if parameter.IsColored() {
+		fmt.Println(generationDiff.Colored())
+	} else {
+		fmt.Println(generationDiff)
+	}
+	rowShift, colShift := generationDiff.GetShift()
+	log.Printf("%d births, %d deaths, %d changes and %d unchanged cells with a shift of %d rows and %d columns\n",
+		generationDiff.GetNumOfBirths(), generationDiff.GetNumOfDeaths(), generationDiff.GetNumOfChanges(), generationDiff.GetNumOfUnchanged(), rowShift, colShift)
+
+	if parameter.GetImagePath() != "" {
+		if err = generationDiff.WriteImage(parameter.GetImagePath()); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+func listPatterns(args []string) {
+	parameter, err := param.NewPatterns(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, pattern := range parameter.GetPatterns() {
+		fmt.Printf("%-16s %3dx%-3d %s\n", pattern.GetName(), pattern.GetWidth(), pattern.GetHeight(), pattern.GetTitle())
+		fmt.Printf("%24s%s\n", "", pattern.GetDescription())
+	}
+}
+
+func validate(args []string) {
+	parameter, err := param.NewValidate(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var problems []error
+	if validator, isValidator := parameter.GetReader().(gameio.Validator); isValidator {
+		problems = validator.Validate(parameter.GetRule().GetNumOfStates())
+	} else if _, err = parameter.GetReader().Read(); err != nil {
+		problems = []error{err}
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		log.Fatalf("%d problems found in %s\n", len(problems), parameter.GetPath())
+	}
+	log.Printf("%s is valid\n", parameter.GetPath())
 }
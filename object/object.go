@@ -0,0 +1,263 @@
+package object
+
+import (
+	"errors"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	NilCellStateError        = "cell state passed is nil"
+	InvalidConnectivityError = "connectivity is invalid (use: Orthogonal/Moore/Pseudo)"
+	NegativeNumOfStepsError  = "number of steps is negative"
+)
+
+var (
+	ErrNilCellState        = errors.New(NilCellStateError)
+	ErrInvalidConnectivity = errors.New(InvalidConnectivityError)
+	ErrNegativeNumOfSteps  = errors.New(NegativeNumOfStepsError)
+)
+
+type Object struct {
+	cellState *cell.CellState
+}
+
+func (object *Object) GetOffset() (int, int) {
+	return object.cellState.GetOffset()
+}
+
+func (object *Object) GetCellState() *cell.CellState {
+	return object.cellState
+}
+
+// Connectivity tells which living cells are taken as touching.
+type Connectivity int
+
+const (
+	// Orthogonal connects cells sharing an edge.
+	Orthogonal Connectivity = iota + 1
+	// Moore connects cells sharing an edge or a corner.
+	Moore
+	// Pseudo connects cells at most two cells apart, so objects close
+	// enough to influence each other stay together.
+	Pseudo
+)
+
+// Separate splits the living cells of cellState into objects of
+// 8-connected cells, ordered by their top-most then left-most cell.
+func Separate(cellState *cell.CellState) ([]*Object, error) {
+	return SeparateWith(cellState, Moore, 0)
+}
+
+// SeparateWith splits the living cells of cellState into objects of cells
+// connected by connectivity. When numOfSteps is more than zero, each object
+// is split further into parts that evolve for numOfSteps generations
+// exactly as they do together, e.g. the two blocks of a bi-block.
+func SeparateWith(cellState *cell.CellState, connectivity Connectivity, numOfSteps int) ([]*Object, error) {
+	if cellState == nil {
+		return nil, ErrNilCellState
+	}
+	if connectivity < Orthogonal || connectivity > Pseudo {
+		return nil, ErrInvalidConnectivity
+	}
+	if numOfSteps < 0 {
+		return nil, ErrNegativeNumOfSteps
+	}
+
+	generation := cellState.GetGeneration()
+	rowOffset, colOffset := cellState.GetOffset()
+	isVisited := make([][]bool, len(generation))
+	for i := 0; i < len(generation); i++ {
+		isVisited[i] = make([]bool, len(generation[i]))
+	}
+
+	objects := make([]*Object, 0)
+	for i := 0; i < len(generation); i++ {
+		for j := 0; j < len(generation[i]); j++ {
+			if !generation[i][j] || isVisited[i][j] {
+				continue
+			}
+
+			components := [][][2]int{collectComponent(generation, isVisited, i, j, connectivity)}
+			if numOfSteps > 0 {
+				components = splitByStepping(components[0], cellState.GetRule(), numOfSteps)
+			}
+			for _, component := range components {
+				objectGeneration, minRow, minCol := toGeneration(component)
+				objectState, err := cell.NewWithOffset(objectGeneration, cellState.GetRule(), rowOffset+minRow, colOffset+minCol)
+				if err != nil {
+					return nil, err
+				}
+				objects = append(objects, &Object{
+					cellState: objectState,
+				})
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+func collectComponent(generation [][]bool, isVisited [][]bool, row, col int, connectivity Connectivity) [][2]int {
+	reach := 1
+	if connectivity == Pseudo {
+		reach = 2
+	}
+
+	component := make([][2]int, 0)
+	stack := [][2]int{{row, col}}
+	isVisited[row][col] = true
+	for len(stack) > 0 {
+		position := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		component = append(component, position)
+
+		for p := position[0] - reach; p <= position[0]+reach; p++ {
+			for q := position[1] - reach; q <= position[1]+reach; q++ {
+				if p < 0 || p >= len(generation) || q < 0 || q >= len(generation[p]) {
+					continue
+				}
+				if connectivity == Orthogonal && p != position[0] && q != position[1] {
+					continue
+				}
+				if generation[p][q] && !isVisited[p][q] {
+					isVisited[p][q] = true
+					stack = append(stack, [2]int{p, q})
+				}
+			}
+		}
+	}
+
+	return component
+}
+
+// splitByStepping starts from the orthogonally connected parts of component
+// and merges every two parts that do not evolve independently. If the parts
+// left still interact as a whole, component is kept as one object.
+func splitByStepping(component [][2]int, cellRule *rule.Rule, numOfSteps int) [][][2]int {
+	generation, minRow, minCol := toGeneration(component)
+	isVisited := make([][]bool, len(generation))
+	for i := 0; i < len(generation); i++ {
+		isVisited[i] = make([]bool, len(generation[i]))
+	}
+
+	parts := make([][][2]int, 0)
+	for i := 0; i < len(generation); i++ {
+		for j := 0; j < len(generation[i]); j++ {
+			if !generation[i][j] || isVisited[i][j] {
+				continue
+			}
+
+			part := collectComponent(generation, isVisited, i, j, Orthogonal)
+			for k := range part {
+				part[k] = [2]int{part[k][0] + minRow, part[k][1] + minCol}
+			}
+			parts = append(parts, part)
+		}
+	}
+
+	for isMerged := true; isMerged && len(parts) > 1; {
+		isMerged = false
+		for i := 0; i < len(parts) && !isMerged; i++ {
+			for j := i + 1; j < len(parts) && !isMerged; j++ {
+				if !isIndependent([][][2]int{parts[i], parts[j]}, cellRule, numOfSteps) {
+					parts[i] = append(parts[i], parts[j]...)
+					parts = append(parts[:j], parts[j+1:]...)
+					isMerged = true
+				}
+			}
+		}
+	}
+	if len(parts) > 1 && !isIndependent(parts, cellRule, numOfSteps) {
+		return [][][2]int{component}
+	}
+
+	return parts
+}
+
+func isIndependent(parts [][][2]int, cellRule *rule.Rule, numOfSteps int) bool {
+	whole := make([][2]int, 0)
+	for _, part := range parts {
+		whole = append(whole, part...)
+	}
+	expected := evolve(whole, cellRule, numOfSteps)
+
+	actual := make([]map[[2]int]bool, numOfSteps)
+	for step := 0; step < numOfSteps; step++ {
+		actual[step] = make(map[[2]int]bool)
+	}
+	for _, part := range parts {
+		for step, cells := range evolve(part, cellRule, numOfSteps) {
+			for position := range cells {
+				if actual[step][position] {
+					return false
+				}
+				actual[step][position] = true
+			}
+		}
+	}
+
+	for step := 0; step < numOfSteps; step++ {
+		if len(actual[step]) != len(expected[step]) {
+			return false
+		}
+		for position := range expected[step] {
+			if !actual[step][position] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func evolve(cells [][2]int, cellRule *rule.Rule, numOfSteps int) []map[[2]int]bool {
+	generation, minRow, minCol := toGeneration(cells)
+	cellState, _ := cell.NewWithOffset(generation, cellRule, minRow, minCol)
+
+	evolution := make([]map[[2]int]bool, numOfSteps)
+	for step := 0; step < numOfSteps; step++ {
+		cellState = cellState.GetNextState()
+		rowOffset, colOffset := cellState.GetOffset()
+		evolution[step] = make(map[[2]int]bool)
+		for i, row := range cellState.GetGeneration() {
+			for j, isAlive := range row {
+				if isAlive {
+					evolution[step][[2]int{rowOffset + i, colOffset + j}] = true
+				}
+			}
+		}
+	}
+
+	return evolution
+}
+
+func toGeneration(component [][2]int) ([][]bool, int, int) {
+	minRow, maxRow := component[0][0], component[0][0]
+	minCol, maxCol := component[0][1], component[0][1]
+	for _, position := range component {
+		if position[0] < minRow {
+			minRow = position[0]
+		}
+		if position[0] > maxRow {
+			maxRow = position[0]
+		}
+		if position[1] < minCol {
+			minCol = position[1]
+		}
+		if position[1] > maxCol {
+			maxCol = position[1]
+		}
+	}
+
+	generation := make([][]bool, maxRow-minRow+1)
+	for i := 0; i < len(generation); i++ {
+		generation[i] = make([]bool, maxCol-minCol+1)
+	}
+	for _, position := range component {
+		generation[position[0]-minRow][position[1]-minCol] = true
+	}
+
+	return generation, minRow, minCol
+}
@@ -0,0 +1,172 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/object"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeparate(t *testing.T) {
+	t.Run("should return nil and error for nil cell state", func(t *testing.T) {
+		var expectedError = object.NilCellStateError
+
+		actualObjects, actualError := object.Separate(nil)
+
+		assert.Nil(t, actualObjects)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return no object for extinct cell state", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{{true}})
+
+		actualObjects, actualError := object.Separate(cellState.GetNextState())
+
+		assert.Empty(t, actualObjects)
+		assert.Nil(t, actualError)
+	})
+
+	t.Run("should return diagonally touching cells as one object", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{
+			{true, false},
+			{false, true},
+		})
+
+		actualObjects, actualError := object.Separate(cellState)
+
+		assert.Len(t, actualObjects, 1)
+		assert.Nil(t, actualError)
+	})
+
+	t.Run("should return each object with its offset and cell state", func(t *testing.T) {
+		cellState, _ := cell.NewWithOffset([][]bool{
+			{true, true, false, false, false},
+			{true, true, false, false, false},
+			{false, false, false, false, false},
+			{false, false, true, true, true},
+		}, rule.Default(), 10, 20)
+		expectedBlock := [][]bool{{true, true}, {true, true}}
+		expectedBlinker := [][]bool{{true, true, true}}
+
+		actualObjects, actualError := object.Separate(cellState)
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualObjects, 2)
+		blockRow, blockCol := actualObjects[0].GetOffset()
+		assert.Equal(t, 10, blockRow)
+		assert.Equal(t, 20, blockCol)
+		assert.EqualValues(t, expectedBlock, actualObjects[0].GetCellState().GetGeneration())
+		blinkerRow, blinkerCol := actualObjects[1].GetOffset()
+		assert.Equal(t, 13, blinkerRow)
+		assert.Equal(t, 22, blinkerCol)
+		assert.EqualValues(t, expectedBlinker, actualObjects[1].GetCellState().GetGeneration())
+	})
+}
+
+func TestSeparateWith(t *testing.T) {
+	biBlock := [][]bool{
+		{true, true, false, true, true},
+		{true, true, false, true, true},
+	}
+	blockAndBlinker := [][]bool{
+		{true, true, false, false, false},
+		{true, true, false, false, false},
+		{false, false, false, true, true},
+	}
+
+	t.Run("should return nil and error for nil cell state", func(t *testing.T) {
+		var expectedError = object.NilCellStateError
+
+		actualObjects, actualError := object.SeparateWith(nil, object.Moore, 0)
+
+		assert.Nil(t, actualObjects)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid connectivity", func(t *testing.T) {
+		cellState, _ := cell.New(biBlock)
+		var expectedError = object.InvalidConnectivityError
+
+		actualObjects, actualError := object.SeparateWith(cellState, object.Connectivity(0), 0)
+
+		assert.Nil(t, actualObjects)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for negative number of steps", func(t *testing.T) {
+		cellState, _ := cell.New(biBlock)
+		var expectedError = object.NegativeNumOfStepsError
+
+		actualObjects, actualError := object.SeparateWith(cellState, object.Moore, -1)
+
+		assert.Nil(t, actualObjects)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return objects by connectivity", func(t *testing.T) {
+		cellState, _ := cell.New([][]bool{
+			{true, false, true, false, true},
+			{false, true, false, false, false},
+		})
+		testCases := []struct {
+			connectivity       object.Connectivity
+			expectedNumObjects int
+		}{
+			{object.Orthogonal, 4},
+			{object.Moore, 2},
+			{object.Pseudo, 1},
+		}
+
+		for _, testCase := range testCases {
+			actualObjects, actualError := object.SeparateWith(cellState, testCase.connectivity, 0)
+
+			assert.Nil(t, actualError)
+			assert.Len(t, actualObjects, testCase.expectedNumObjects)
+		}
+	})
+
+	t.Run("should return parts that evolve independently by stepping", func(t *testing.T) {
+		cellState, _ := cell.NewWithOffset(biBlock, rule.Default(), 5, 5)
+		expectedBlock := [][]bool{{true, true}, {true, true}}
+
+		actualObjects, actualError := object.SeparateWith(cellState, object.Pseudo, 4)
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualObjects, 2)
+		firstRow, firstCol := actualObjects[0].GetOffset()
+		secondRow, secondCol := actualObjects[1].GetOffset()
+		assert.Equal(t, [2]int{5, 5}, [2]int{firstRow, firstCol})
+		assert.Equal(t, [2]int{5, 8}, [2]int{secondRow, secondCol})
+		assert.EqualValues(t, expectedBlock, actualObjects[0].GetCellState().GetGeneration())
+		assert.EqualValues(t, expectedBlock, actualObjects[1].GetCellState().GetGeneration())
+	})
+
+	t.Run("should keep parts that interact together by stepping", func(t *testing.T) {
+		testCases := []struct {
+			generation [][]bool
+		}{
+			{[][]bool{
+				{false, true, false},
+				{false, false, true},
+				{true, true, true},
+			}},
+			{[][]bool{
+				{false, true, true, false},
+				{true, false, false, true},
+				{false, true, true, false},
+			}},
+			{blockAndBlinker},
+		}
+
+		for _, testCase := range testCases {
+			cellState, _ := cell.New(testCase.generation)
+
+			actualObjects, actualError := object.SeparateWith(cellState, object.Pseudo, 4)
+
+			assert.Nil(t, actualError)
+			assert.Len(t, actualObjects, 1)
+		}
+	})
+}
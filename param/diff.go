@@ -0,0 +1,137 @@
+package param
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+
+	"github.com/irainia/gameoflife-go/diff"
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	NoBeforePathError     = "no before path provided (use: --before=[pattern path *.cell, *.rle or *.json])"
+	InvalidTranslateError = "invalid translate (use: true/false)"
+	InvalidColorError     = "invalid color (use: true/false)"
+)
+
+var (
+	ErrNoBeforePath     = errors.New(NoBeforePathError)
+	ErrInvalidTranslate = errors.New(InvalidTranslateError)
+	ErrInvalidColor     = errors.New(InvalidColorError)
+)
+
+const (
+	beforePath = "--before"
+	afterPath  = "--after"
+	translate  = "--translate"
+	colored    = "--color"
+
+	defaultDiffGeneration = "0"
+	defaultTranslate      = "false"
+	defaultColored        = "true"
+)
+
+type DiffParam struct {
+	numOfGeneration int
+	isTranslated    bool
+	isColored       bool
+	imagePath       string
+	rule            *rule.Rule
+
+	beforeStream io.Reader
+	afterStream  io.Reader
+}
+
+// GetNumOfGeneration returns the number of generations the after pattern
+// is evolved before it is compared.
+func (parameter *DiffParam) GetNumOfGeneration() int {
+	return parameter.numOfGeneration
+}
+
+func (parameter *DiffParam) IsTranslated() bool {
+	return parameter.isTranslated
+}
+
+func (parameter *DiffParam) IsColored() bool {
+	return parameter.isColored
+}
+
+// GetImagePath returns the path to write the diff image to, empty when
+// there is none.
+func (parameter *DiffParam) GetImagePath() string {
+	return parameter.imagePath
+}
+
+func (parameter *DiffParam) GetRule() *rule.Rule {
+	return parameter.rule
+}
+
+func (parameter *DiffParam) GetBeforeReader() io.Reader {
+	return parameter.beforeStream
+}
+
+// GetAfterReader returns the reader of the after pattern, the before
+// pattern again when no after path is given.
+func (parameter *DiffParam) GetAfterReader() io.Reader {
+	return parameter.afterStream
+}
+
+func NewDiff(args []string) (*DiffParam, error) {
+	mappedArgs, err := mapCommandArgs(args, beforePath, afterPath, generation, translate, colored, cellRule, outputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if mappedArgs[beforePath] == emptyArgument {
+		return nil, ErrNoBeforePath
+	}
+	if mappedArgs[outputPath] != emptyArgument && filepath.Ext(mappedArgs[outputPath]) != diff.ImageExtension {
+		return nil, diff.ErrInvalidExtension
+	}
+
+	numOfGeneration, err := strconv.ParseInt(valueOrDefault(mappedArgs[generation], defaultDiffGeneration), baseConvert, bitSizeConvert)
+	if err != nil || numOfGeneration < 0 {
+		return nil, ErrInvalidGeneration
+	}
+	isTranslated, err := strconv.ParseBool(valueOrDefault(mappedArgs[translate], defaultTranslate))
+	if err != nil {
+		return nil, ErrInvalidTranslate
+	}
+	isColored, err := strconv.ParseBool(valueOrDefault(mappedArgs[colored], defaultColored))
+	if err != nil {
+		return nil, ErrInvalidColor
+	}
+
+	parsedRule := rule.Default()
+	if mappedArgs[cellRule] != emptyArgument {
+		parsedRule, err = rule.Load(mappedArgs[cellRule])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	beforeReader, err := newFileReader(mappedArgs[beforePath])
+	if err != nil {
+		return nil, err
+	}
+	afterReader := beforeReader
+	if mappedArgs[afterPath] != emptyArgument {
+		afterReader, err = newFileReader(mappedArgs[afterPath])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parameter = DiffParam{
+		numOfGeneration: int(numOfGeneration),
+		isTranslated:    isTranslated,
+		isColored:       isColored,
+		imagePath:       mappedArgs[outputPath],
+		rule:            parsedRule,
+		beforeStream:    beforeReader,
+		afterStream:     afterReader,
+	}
+	return &parameter, nil
+}
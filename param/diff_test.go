@@ -0,0 +1,84 @@
+package param_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/diff"
+	"github.com/irainia/gameoflife-go/param"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDiff(t *testing.T) {
+	t.Run("should return nil and error for no before path", func(t *testing.T) {
+		var expectedError = param.NoBeforePathError
+
+		actualParam, actualError := param.NewDiff([]string{"--after=after.cell"})
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid values", func(t *testing.T) {
+		testCases := []struct {
+			arg           string
+			expectedError string
+		}{
+			{"--outputpath=diff.cell", diff.InvalidExtensionError},
+			{"--generation=-1", param.InvalidGenerationError},
+			{"--generation=many", param.InvalidGenerationError},
+			{"--translate=maybe", param.InvalidTranslateError},
+			{"--color=maybe", param.InvalidColorError},
+			{"--rule=B3", rule.InvalidNotationError},
+			{"--inputpath=input.cell", param.UnknownArgumentError},
+		}
+
+		for _, testCase := range testCases {
+			args := []string{"--before=before.cell", testCase.arg}
+
+			actualParam, actualError := param.NewDiff(args)
+
+			assert.Nil(t, actualParam)
+			assert.EqualError(t, actualError, testCase.expectedError)
+		}
+	})
+
+	t.Run("should return defaults for before path only", func(t *testing.T) {
+		var args []string = []string{
+			"--before=before.cell",
+		}
+
+		actualParam, actualError := param.NewDiff(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 0, actualParam.GetNumOfGeneration())
+		assert.False(t, actualParam.IsTranslated())
+		assert.True(t, actualParam.IsColored())
+		assert.Equal(t, "", actualParam.GetImagePath())
+		assert.Equal(t, rule.Conway, actualParam.GetRule().String())
+		assert.NotNil(t, actualParam.GetBeforeReader())
+		assert.Equal(t, actualParam.GetBeforeReader(), actualParam.GetAfterReader())
+	})
+
+	t.Run("should return the same values as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--before=before.cell",
+			"--after=after.rle",
+			"--generation=4",
+			"--translate=true",
+			"--color=false",
+			"--rule=B36/S23",
+			"--outputpath=diff.png",
+		}
+
+		actualParam, actualError := param.NewDiff(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 4, actualParam.GetNumOfGeneration())
+		assert.True(t, actualParam.IsTranslated())
+		assert.False(t, actualParam.IsColored())
+		assert.Equal(t, "diff.png", actualParam.GetImagePath())
+		assert.Equal(t, "B36/S23", actualParam.GetRule().String())
+		assert.NotEqual(t, actualParam.GetBeforeReader(), actualParam.GetAfterReader())
+	})
+}
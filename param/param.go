@@ -2,11 +2,22 @@ package param
 
 import (
 	"errors"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/irainia/gameoflife-go/checkpoint"
 	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/composition"
 	"github.com/irainia/gameoflife-go/io/file"
+	"github.com/irainia/gameoflife-go/io/png"
+	"github.com/irainia/gameoflife-go/io/random"
+	"github.com/irainia/gameoflife-go/io/rle"
+	"github.com/irainia/gameoflife-go/library"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/irainia/gameoflife-go/stats"
+	"github.com/irainia/gameoflife-go/transform"
 )
 
 const (
@@ -15,45 +26,140 @@ const (
 
 	UnknownArgumentError = "unknown argument"
 
-	NoInputTypeError           = "no input type provided (use: --inputtype=[file/custom])"
-	UnknownInputTypeValueError = "unknown input type value (use: file/custom)"
-	NoInputPathError           = "no input path provided (use: --inputpath=[input path *.cell])"
+	NoInputTypeError           = "no input type provided (use: --inputtype=[file/custom/random/library or the name of a registered reader])"
+	UnknownInputTypeValueError = "unknown input type value (use: file/custom/random/library or the name of a registered reader)"
+	NoInputPathError           = "no input path provided (use: --inputpath=[input path *.cell, *.rle or *.json])"
+	NoPatternError             = "no pattern provided (use: --pattern=[pattern name], see the patterns command)"
 
-	NoOutputTypeError           = "no output type provided (use: --outputtype=[file/custom])"
-	UnknownOutputTypeValueError = "unknown output type value (use: file/custom)"
-	NoOutputPathError           = "no output path provided (use: --outputpath=[output path *.cell])"
+	NoOutputTypeError           = "no output type provided (use: --outputtype=[file/custom or the name of a registered writer])"
+	UnknownOutputTypeValueError = "unknown output type value (use: file/custom or the name of a registered writer)"
+	NoOutputPathError           = "no output path provided (use: --outputpath=[output path *.cell or *.png])"
 
 	NoGenerationError          = "no generation provided (use: --generation=[number of generation])"
 	InvalidGenerationError     = "invalid generation (should be whole number)"
 	LessThanOneGenerationError = "generation is less than one (should be at least 1)"
 
+	InvalidWidthError   = "invalid width (should be whole number)"
+	InvalidHeightError  = "invalid height (should be whole number)"
+	InvalidDensityError = "invalid density (should be decimal number, e.g. 0.5)"
+	InvalidSeedError    = "invalid seed (should be whole number)"
+
+	InvalidTimeoutError  = "invalid timeout (use: --timeout=[duration], e.g. 30s or 5m)"
+	NegativeTimeoutError = "timeout is negative"
+
+	NoCheckpointDirError        = "no checkpoint directory provided (use: --checkpoint-dir=[directory])"
+	NoCheckpointEveryError      = "no checkpoint interval provided (use: --checkpoint-every=[number of generation])"
+	InvalidCheckpointEveryError = "invalid checkpoint interval (should be whole number more than zero)"
+
+	ResumeConflictError         = "resume cannot be combined with input type, input path, rule or transform"
+	ResumeBeyondGenerationError = "checkpoint is beyond the number of generation"
+
+	RuleConflictError = "rule is not the one of the composition (leave out --rule to use the rule of the composition)"
+
+	UnknownRenderValueError = "unknown render value (use: generation/age/heat)"
+	NoHeatWriterError       = "rendering ages or heat needs an image output (use: --outputpath=[output path *.png])"
+
 	NoSeparatorError = "no separator (use separator '=')"
 
 	NoCustomReaderError = "no custom reader provided"
 	NoCustomWriterError = "no custom writer provided"
 )
 
+var (
+	ErrNilArgs                = errors.New(NilArgsError)
+	ErrEmptyArgs              = errors.New(EmptyArgsError)
+	ErrUnknownArgument        = errors.New(UnknownArgumentError)
+	ErrNoInputType            = errors.New(NoInputTypeError)
+	ErrUnknownInputTypeValue  = errors.New(UnknownInputTypeValueError)
+	ErrNoInputPath            = errors.New(NoInputPathError)
+	ErrNoPattern              = errors.New(NoPatternError)
+	ErrNoOutputType           = errors.New(NoOutputTypeError)
+	ErrUnknownOutputTypeValue = errors.New(UnknownOutputTypeValueError)
+	ErrNoOutputPath           = errors.New(NoOutputPathError)
+	ErrNoGeneration           = errors.New(NoGenerationError)
+	ErrInvalidGeneration      = errors.New(InvalidGenerationError)
+	ErrLessThanOneGeneration  = errors.New(LessThanOneGenerationError)
+	ErrInvalidWidth           = errors.New(InvalidWidthError)
+	ErrInvalidHeight          = errors.New(InvalidHeightError)
+	ErrInvalidDensity         = errors.New(InvalidDensityError)
+	ErrInvalidSeed            = errors.New(InvalidSeedError)
+	ErrInvalidTimeout         = errors.New(InvalidTimeoutError)
+	ErrNegativeTimeout        = errors.New(NegativeTimeoutError)
+	ErrNoCheckpointDir        = errors.New(NoCheckpointDirError)
+	ErrNoCheckpointEvery      = errors.New(NoCheckpointEveryError)
+	ErrInvalidCheckpointEvery = errors.New(InvalidCheckpointEveryError)
+	ErrResumeConflict         = errors.New(ResumeConflictError)
+	ErrResumeBeyondGeneration = errors.New(ResumeBeyondGenerationError)
+	ErrRuleConflict           = errors.New(RuleConflictError)
+	ErrUnknownRenderValue     = errors.New(UnknownRenderValueError)
+	ErrNoHeatWriter           = errors.New(NoHeatWriterError)
+	ErrNoSeparator            = errors.New(NoSeparatorError)
+	ErrNoCustomReader         = errors.New(NoCustomReaderError)
+	ErrNoCustomWriter         = errors.New(NoCustomWriterError)
+)
+
 const (
 	inputType  = "--inputtype"
 	inputPath  = "--inputpath"
 	outputType = "--outputtype"
 	outputPath = "--outputpath"
 	generation = "--generation"
+	cellRule   = "--rule"
+	timeout    = "--timeout"
+
+	checkpointEvery = "--checkpoint-every"
+	checkpointDir   = "--checkpoint-dir"
+	resume          = "--resume"
+	statsPath       = "--stats"
+	inputTransform  = "--transform"
+	render          = "--render"
+	patternName     = "--pattern"
+	inputOptions    = "--inputoptions"
+	outputOptions   = "--outputoptions"
+
+	soupWidth    = "--width"
+	soupHeight   = "--height"
+	soupDensity  = "--density"
+	soupSeed     = "--seed"
+	soupSymmetry = "--symmetry"
 
-	ioTypeFile   = "file"
-	ioTypeCustom = "custom"
+	ioTypeFile    = "file"
+	ioTypeCustom  = "custom"
+	ioTypeRandom  = "random"
+	ioTypeLibrary = "library"
+
+	RenderGeneration = "generation"
+	RenderAge        = "age"
+	RenderHeat       = "heat"
 
 	emptyArgument     = ""
 	argumentSeparator = "="
 
-	minGeneration  = 1
-	baseConvert    = 10
-	bitSizeConvert = 32
+	minGeneration      = 1
+	minCheckpointEvery = 1
+	baseConvert        = 10
+	bitSizeConvert     = 32
+	bitSizeSeed        = 64
+	bitSizeDensity     = 64
+
+	defaultSoupWidth   = "16"
+	defaultSoupHeight  = "16"
+	defaultSoupDensity = "0.5"
 )
 
 type Param struct {
 	numOfGeneration int
+	rule            *rule.Rule
+	timeout         time.Duration
+
+	checkpointEvery  int
+	checkpointDir    string
+	resumeCheckpoint *checkpoint.Checkpoint
 
+	statsRecorder *stats.Recorder
+
+	transform   *transform.Transform
+	render      string
 	readStream  io.Reader
 	writeStream io.Writer
 }
@@ -62,6 +168,40 @@ func (parameter *Param) GetNumOfGeneration() int {
 	return parameter.numOfGeneration
 }
 
+func (parameter *Param) GetRule() *rule.Rule {
+	return parameter.rule
+}
+
+func (parameter *Param) GetTimeout() time.Duration {
+	return parameter.timeout
+}
+
+func (parameter *Param) GetCheckpointEvery() int {
+	return parameter.checkpointEvery
+}
+
+func (parameter *Param) GetCheckpointDir() string {
+	return parameter.checkpointDir
+}
+
+func (parameter *Param) GetResumeCheckpoint() *checkpoint.Checkpoint {
+	return parameter.resumeCheckpoint
+}
+
+func (parameter *Param) GetStatsRecorder() *stats.Recorder {
+	return parameter.statsRecorder
+}
+
+func (parameter *Param) GetTransform() *transform.Transform {
+	return parameter.transform
+}
+
+// GetRender returns what the output shows: the generation, or the ages or
+// the heat map tracked over the run.
+func (parameter *Param) GetRender() string {
+	return parameter.render
+}
+
 func (parameter *Param) GetReader() io.Reader {
 	return parameter.readStream
 }
@@ -72,91 +212,319 @@ func (parameter *Param) GetWriter() io.Writer {
 
 func New(args []string, reader io.Reader, writer io.Writer) (*Param, error) {
 	if args == nil {
-		return nil, errors.New(NilArgsError)
+		return nil, ErrNilArgs
 	}
 	if len(args) == 0 {
-		return nil, errors.New(EmptyArgsError)
+		return nil, ErrEmptyArgs
 	}
 
 	mappedArgs, err := mapArgs(args)
 	if err != nil {
 		return nil, err
 	}
-	validationResult := validateMappedArgs(mappedArgs, reader, writer)
+	isResuming := mappedArgs[resume] != emptyArgument
+	validationResult := validateMappedArgs(mappedArgs, reader, writer, isResuming)
 	if validationResult != nil {
 		return nil, validationResult
 	}
 
+	var resumeCheckpoint *checkpoint.Checkpoint
+	if isResuming {
+		resumeCheckpoint, err = checkpoint.Load(mappedArgs[resume])
+		if err != nil {
+			return nil, err
+		}
+		if mappedArgs[generation] == emptyArgument {
+			mappedArgs[generation] = strconv.Itoa(resumeCheckpoint.GetNumOfGeneration())
+		}
+	}
+
 	numOfGeneration, err := strconv.ParseInt(mappedArgs[generation], baseConvert, bitSizeConvert)
 	if err != nil {
-		return nil, errors.New(InvalidGenerationError)
+		return nil, ErrInvalidGeneration
 	}
 	if numOfGeneration < minGeneration {
-		return nil, errors.New(LessThanOneGenerationError)
+		return nil, ErrLessThanOneGeneration
+	}
+	if resumeCheckpoint != nil && int(numOfGeneration) < resumeCheckpoint.GetGeneration() {
+		return nil, ErrResumeBeyondGeneration
 	}
 
-	if mappedArgs[inputType] == ioTypeFile {
-		reader, err = file.New(mappedArgs[inputPath])
+	checkpointInterval, err := parseCheckpointArgs(mappedArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedRule := rule.Default()
+	if resumeCheckpoint != nil {
+		parsedRule = resumeCheckpoint.GetCellState().GetRule()
+	} else if mappedArgs[cellRule] != emptyArgument {
+		parsedRule, err = rule.Load(mappedArgs[cellRule])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	runTimeout, err := parseTimeout(mappedArgs[timeout])
+	if err != nil {
+		return nil, err
+	}
+
+	var statsRecorder *stats.Recorder
+	if mappedArgs[statsPath] != emptyArgument {
+		statsRecorder, err = stats.New(mappedArgs[statsPath])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inputTransformation, err := transform.Get(valueOrDefault(mappedArgs[inputTransform], transform.Identity))
+	if err != nil {
+		return nil, err
+	}
+
+	switch mappedArgs[inputType] {
+	case ioTypeFile:
+		reader, err = newFileReader(mappedArgs[inputPath])
+		if err != nil {
+			return nil, err
+		}
+	case ioTypeRandom:
+		reader, err = newSoupStream(mappedArgs)
+		if err != nil {
+			return nil, err
+		}
+	case ioTypeLibrary:
+		reader, err = newLibraryReader(mappedArgs[patternName])
+		if err != nil {
+			return nil, err
+		}
+	case ioTypeCustom, emptyArgument:
+	default:
+		reader, err = io.NewReader(mappedArgs[inputType], mappedArgs[inputOptions])
 		if err != nil {
 			return nil, err
 		}
 	}
-	if mappedArgs[outputType] == ioTypeFile {
-		writer, err = file.New(mappedArgs[outputPath])
+	if compositionStream, isComposition := reader.(*composition.CompositionStream); isComposition {
+		parsedRule, err = withCompositionRule(compositionStream, parsedRule, mappedArgs[cellRule] != emptyArgument)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	switch mappedArgs[outputType] {
+	case ioTypeFile:
+		writer, err = newFileWriter(mappedArgs[outputPath])
+		if err != nil {
+			return nil, err
+		}
+	case ioTypeCustom:
+	default:
+		writer, err = io.NewWriter(mappedArgs[outputType], mappedArgs[outputOptions])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	outputRender := valueOrDefault(mappedArgs[render], RenderGeneration)
+	if outputRender != RenderGeneration && outputRender != RenderAge && outputRender != RenderHeat {
+		return nil, ErrUnknownRenderValue
+	}
+	if _, isHeatWriter := writer.(io.HeatWriter); outputRender != RenderGeneration && !isHeatWriter {
+		return nil, ErrNoHeatWriter
+	}
+
 	var param = Param{
 		numOfGeneration: int(numOfGeneration),
-		readStream:      reader,
-		writeStream:     writer,
+		rule:            parsedRule,
+		timeout:         runTimeout,
+
+		checkpointEvery:  checkpointInterval,
+		checkpointDir:    mappedArgs[checkpointDir],
+		resumeCheckpoint: resumeCheckpoint,
+
+		statsRecorder: statsRecorder,
+
+		transform:   inputTransformation,
+		render:      outputRender,
+		readStream:  reader,
+		writeStream: writer,
 	}
 	return &param, nil
 }
 
-func validateMappedArgs(mappedArgs map[string]string, reader io.Reader, writer io.Writer) error {
+// newFileReader reads run length encoded files and compositions by their
+// extension and cell files otherwise.
+func newFileReader(path string) (io.Reader, error) {
+	switch filepath.Ext(path) {
+	case rle.FileExtension:
+		return rle.New(path)
+	case composition.FileExtension:
+		return composition.New(path)
+	default:
+		return file.New(path)
+	}
+}
+
+// withCompositionRule returns the rule of compositionStream when it has one
+// and no rule is passed, and parsedRule otherwise. A rule passed that is not
+// the one of the composition is an error, as its phases are run with it.
+func withCompositionRule(compositionStream *composition.CompositionStream, parsedRule *rule.Rule, isRulePassed bool) (*rule.Rule, error) {
+	notation, err := compositionStream.GetRule()
+	if err != nil || notation == "" {
+		return parsedRule, err
+	}
+
+	compositionRule, err := rule.Load(notation)
+	if err != nil {
+		return nil, err
+	}
+	if isRulePassed && compositionRule.String() != parsedRule.String() {
+		return nil, ErrRuleConflict
+	}
+	return compositionRule, nil
+}
+
+// newFileWriter writes images by their extension and cell files otherwise.
+func newFileWriter(path string) (io.Writer, error) {
+	if filepath.Ext(path) == png.FileExtension {
+		return png.New(path)
+	}
+	return file.New(path)
+}
+
+// newLibraryReader reads the pattern of name embedded in the binary.
+func newLibraryReader(name string) (io.Reader, error) {
+	pattern, err := library.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return pattern.GetReader()
+}
+
+func newSoupStream(mappedArgs map[string]string) (*random.SoupStream, error) {
+	width, err := strconv.ParseInt(valueOrDefault(mappedArgs[soupWidth], defaultSoupWidth), baseConvert, bitSizeConvert)
+	if err != nil {
+		return nil, ErrInvalidWidth
+	}
+	height, err := strconv.ParseInt(valueOrDefault(mappedArgs[soupHeight], defaultSoupHeight), baseConvert, bitSizeConvert)
+	if err != nil {
+		return nil, ErrInvalidHeight
+	}
+	density, err := strconv.ParseFloat(valueOrDefault(mappedArgs[soupDensity], defaultSoupDensity), bitSizeDensity)
+	if err != nil {
+		return nil, ErrInvalidDensity
+	}
+
+	seed := time.Now().UnixNano()
+	if mappedArgs[soupSeed] != emptyArgument {
+		seed, err = strconv.ParseInt(mappedArgs[soupSeed], baseConvert, bitSizeSeed)
+		if err != nil {
+			return nil, ErrInvalidSeed
+		}
+	}
+
+	return random.New(int(width), int(height), density, seed, mappedArgs[soupSymmetry])
+}
+
+// parseTimeout returns no limit for an empty value.
+func parseTimeout(value string) (time.Duration, error) {
+	if value == emptyArgument {
+		return 0, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, ErrInvalidTimeout
+	}
+	if parsed < 0 {
+		return 0, ErrNegativeTimeout
+	}
+	return parsed, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == emptyArgument {
+		return defaultValue
+	}
+	return value
+}
+
+func parseCheckpointArgs(mappedArgs map[string]string) (int, error) {
+	if mappedArgs[checkpointEvery] == emptyArgument {
+		if mappedArgs[checkpointDir] != emptyArgument {
+			return 0, ErrNoCheckpointEvery
+		}
+		return 0, nil
+	}
+	if mappedArgs[checkpointDir] == emptyArgument {
+		return 0, ErrNoCheckpointDir
+	}
+
+	interval, err := strconv.ParseInt(mappedArgs[checkpointEvery], baseConvert, bitSizeConvert)
+	if err != nil || interval < minCheckpointEvery {
+		return 0, ErrInvalidCheckpointEvery
+	}
+
+	return int(interval), nil
+}
+
+func validateMappedArgs(mappedArgs map[string]string, reader io.Reader, writer io.Writer, isResuming bool) error {
+	if isResuming {
+		if mappedArgs[inputType] != emptyArgument || mappedArgs[inputPath] != emptyArgument ||
+			mappedArgs[inputOptions] != emptyArgument || mappedArgs[cellRule] != emptyArgument ||
+			mappedArgs[inputTransform] != emptyArgument {
+			return ErrResumeConflict
+		}
+	}
+
 	argumentCheckList := []struct {
 		streamType          string
 		streamPath          string
-		noStreamTypeError   string
-		noStreamPathError   string
-		noCustomStreamError string
+		noStreamTypeError   error
+		noStreamPathError   error
+		noCustomStreamError error
 		stream              interface{}
 	}{
 		{
 			streamType:          inputType,
 			streamPath:          inputPath,
-			noStreamTypeError:   NoInputTypeError,
-			noStreamPathError:   NoInputPathError,
-			noCustomStreamError: NoCustomReaderError,
+			noStreamTypeError:   ErrNoInputType,
+			noStreamPathError:   ErrNoInputPath,
+			noCustomStreamError: ErrNoCustomReader,
 			stream:              reader,
 		}, {
 			streamType:          outputType,
 			streamPath:          outputPath,
-			noStreamTypeError:   NoOutputTypeError,
-			noStreamPathError:   NoOutputPathError,
-			noCustomStreamError: NoCustomWriterError,
+			noStreamTypeError:   ErrNoOutputType,
+			noStreamPathError:   ErrNoOutputPath,
+			noCustomStreamError: ErrNoCustomWriter,
 			stream:              writer,
 		},
 	}
+	if isResuming {
+		argumentCheckList = argumentCheckList[1:]
+	}
 	for _, argumentCheck := range argumentCheckList {
 		switch mappedArgs[argumentCheck.streamType] {
 		case emptyArgument:
-			return errors.New(argumentCheck.noStreamTypeError)
+			return argumentCheck.noStreamTypeError
 		case ioTypeFile:
 			if mappedArgs[argumentCheck.streamPath] == emptyArgument {
-				return errors.New(argumentCheck.noStreamPathError)
+				return argumentCheck.noStreamPathError
 			}
 		case ioTypeCustom:
 			if argumentCheck.stream == nil {
-				return errors.New(argumentCheck.noCustomStreamError)
+				return argumentCheck.noCustomStreamError
 			}
 		}
 	}
-	if mappedArgs[generation] == emptyArgument {
-		return errors.New(NoGenerationError)
+	if mappedArgs[inputType] == ioTypeLibrary && mappedArgs[patternName] == emptyArgument {
+		return ErrNoPattern
+	}
+	if mappedArgs[generation] == emptyArgument && !isResuming {
+		return ErrNoGeneration
 	}
 
 	return nil
@@ -165,25 +533,65 @@ func validateMappedArgs(mappedArgs map[string]string, reader io.Reader, writer i
 func mapArgs(args []string) (map[string]string, error) {
 	mappedArgs := make(map[string]string)
 	for i := 0; i < len(args); i++ {
-		arg := strings.Split(args[i], argumentSeparator)
+		// the options of registered streams may hold separators themselves
+		arg := strings.SplitN(args[i], argumentSeparator, 2)
 		if len(arg) == 2 {
 			switch arg[0] {
 			case inputType, outputType:
-				if !(arg[1] == ioTypeFile || arg[1] == ioTypeCustom) {
+				isInputOnly := arg[0] == inputType && (arg[1] == ioTypeRandom || arg[1] == ioTypeLibrary)
+				isRegistered := arg[0] == inputType && contains(io.GetReaderNames(), arg[1]) ||
+					arg[0] == outputType && contains(io.GetWriterNames(), arg[1])
+				if !(arg[1] == ioTypeFile || arg[1] == ioTypeCustom || isInputOnly || isRegistered) {
 					if arg[0] == inputType {
-						return nil, errors.New(UnknownInputTypeValueError)
+						return nil, ErrUnknownInputTypeValue
 					}
-					return nil, errors.New(UnknownOutputTypeValueError)
+					return nil, ErrUnknownOutputTypeValue
 				}
 				fallthrough
-			case inputPath, outputPath, generation:
+			case inputPath, outputPath, generation, cellRule, timeout,
+				checkpointEvery, checkpointDir, resume, statsPath, inputTransform, render, patternName,
+				inputOptions, outputOptions,
+				soupWidth, soupHeight, soupDensity, soupSeed, soupSymmetry:
 				mappedArgs[arg[0]] = arg[1]
 				continue
 			default:
-				return nil, errors.New(UnknownArgumentError)
+				return nil, ErrUnknownArgument
 			}
 		}
-		return nil, errors.New(NoSeparatorError)
+		return nil, ErrNoSeparator
+	}
+
+	return mappedArgs, nil
+}
+
+func contains(names []string, name string) bool {
+	for _, registeredName := range names {
+		if registeredName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func mapCommandArgs(args []string, knownArguments ...string) (map[string]string, error) {
+	mappedArgs := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := strings.SplitN(args[i], argumentSeparator, 2)
+		if len(arg) != 2 {
+			return nil, ErrNoSeparator
+		}
+
+		isKnown := false
+		for _, knownArgument := range knownArguments {
+			if arg[0] == knownArgument {
+				isKnown = true
+				break
+			}
+		}
+		if !isKnown {
+			return nil, ErrUnknownArgument
+		}
+		mappedArgs[arg[0]] = arg[1]
 	}
 
 	return mappedArgs, nil
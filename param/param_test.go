@@ -1,13 +1,28 @@
 package param_test
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/checkpoint"
 	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/composition"
 	"github.com/irainia/gameoflife-go/io/file"
+	"github.com/irainia/gameoflife-go/io/png"
+	"github.com/irainia/gameoflife-go/io/random"
+	"github.com/irainia/gameoflife-go/io/rle"
+	"github.com/irainia/gameoflife-go/library"
 	"github.com/irainia/gameoflife-go/param"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/irainia/gameoflife-go/stats"
+	"github.com/irainia/gameoflife-go/transform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -165,6 +180,21 @@ func TestNew(t *testing.T) {
 		assert.EqualError(t, actualError, expectedError)
 	})
 
+	t.Run("should return error telling invalid generation apart", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=invalid",
+		}
+
+		_, actualError := param.New(args, nil, nil)
+
+		assert.True(t, errors.Is(actualError, param.ErrInvalidGeneration))
+		assert.False(t, errors.Is(actualError, param.ErrNoGeneration))
+	})
+
 	t.Run("should return nil and error for less than one generation", func(t *testing.T) {
 		var args []string = []string{
 			"--inputtype=file",
@@ -243,6 +273,57 @@ func TestNew(t *testing.T) {
 		assert.NotNil(t, actualError)
 	})
 
+	t.Run("should return nil and error for invalid rule", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=1",
+			"--rule=B9/S23",
+		}
+		var expectedError = rule.InvalidNotationError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid timeout", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=1",
+			"--timeout=soon",
+		}
+		var expectedError = param.InvalidTimeoutError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for negative timeout", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=1",
+			"--timeout=-1s",
+		}
+		var expectedError = param.NegativeTimeoutError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
 	t.Run("should return param and nil for valid args", func(t *testing.T) {
 		var args []string = []string{
 			"--inputtype=file",
@@ -277,42 +358,919 @@ func TestGetNumberOfGeneration(t *testing.T) {
 	})
 }
 
-func TestGetReader(t *testing.T) {
-	t.Run("should return the same reader as parameter", func(t *testing.T) {
-		var path string = "./input.cell"
+func TestGetRule(t *testing.T) {
+	t.Run("should return conway rule for no rule", func(t *testing.T) {
 		var args []string = []string{
 			"--inputtype=file",
-			fmt.Sprintf("--inputpath=%s", path),
+			"--inputpath=./input.cell",
 			"--outputtype=file",
 			"--outputpath=./output.cell",
 			"--generation=10",
 		}
 		parameter, _ := param.New(args, nil, nil)
-		fileStream, _ := file.New(path)
-		var expectedReader io.Reader = fileStream
+		var expectedRule = rule.Conway
 
-		actualReader := parameter.GetReader()
+		actualRule := parameter.GetRule()
 
-		assert.Equal(t, reflect.TypeOf(expectedReader), reflect.TypeOf(actualReader))
+		assert.Equal(t, expectedRule, actualRule.String())
+	})
+
+	t.Run("should return the same rule as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--rule=B36/S23",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		var expectedRule = "B36/S23"
+
+		actualRule := parameter.GetRule()
+
+		assert.Equal(t, expectedRule, actualRule.String())
 	})
 }
 
-func TestGetWriter(t *testing.T) {
-	t.Run("should return the same writer as parameter", func(t *testing.T) {
-		var path string = "./output.cell"
+func TestGetTimeout(t *testing.T) {
+	t.Run("should return zero for no timeout", func(t *testing.T) {
 		var args []string = []string{
 			"--inputtype=file",
 			"--inputpath=./input.cell",
 			"--outputtype=file",
-			fmt.Sprintf("--outputpath=%s", path),
+			"--outputpath=./output.cell",
 			"--generation=10",
 		}
 		parameter, _ := param.New(args, nil, nil)
-		fileStream, _ := file.New(path)
-		var expectedReader io.Writer = fileStream
 
-		actualReader := parameter.GetWriter()
+		actualTimeout := parameter.GetTimeout()
 
-		assert.Equal(t, reflect.TypeOf(expectedReader), reflect.TypeOf(actualReader))
+		assert.Equal(t, time.Duration(0), actualTimeout)
+	})
+
+	t.Run("should return the same timeout as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--timeout=1m30s",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		var expectedTimeout = 90 * time.Second
+
+		actualTimeout := parameter.GetTimeout()
+
+		assert.Equal(t, expectedTimeout, actualTimeout)
+	})
+}
+
+func TestGetStatsRecorder(t *testing.T) {
+	t.Run("should return nil for no stats", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+
+		actualRecorder := parameter.GetStatsRecorder()
+
+		assert.Nil(t, actualRecorder)
+	})
+
+	t.Run("should return nil and error for invalid stats extension", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--stats=./stats.txt",
+		}
+		var expectedError = stats.InvalidExtensionError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return recorder for the same path as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--stats=./stats.csv",
+		}
+		parameter, _ := param.New(args, nil, nil)
+
+		actualRecorder := parameter.GetStatsRecorder()
+
+		assert.Equal(t, "./stats.csv", actualRecorder.GetPath())
+	})
+}
+
+func TestGetTransform(t *testing.T) {
+	t.Run("should return nil and error for unknown transform", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--transform=rot45",
+		}
+		var expectedError = transform.UnknownTransformError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return identity transform for no transform", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		generation := [][]bool{{true, false}}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.EqualValues(t, generation, actualParam.GetTransform().Apply(generation))
+	})
+
+	t.Run("should return the same transform as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--transform=rot90",
+		}
+		generation := [][]bool{{true, false}}
+		expectedGeneration := [][]bool{{true}, {false}}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.EqualValues(t, expectedGeneration, actualParam.GetTransform().Apply(generation))
+	})
+}
+
+func TestRandomInput(t *testing.T) {
+	t.Run("should return nil and error for random output type", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=random",
+		}
+		var expectedError = param.UnknownOutputTypeValueError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid width", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=random",
+			"--width=wide",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		var expectedError = param.InvalidWidthError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid density", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=random",
+			"--density=half",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		var expectedError = param.InvalidDensityError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid seed", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=random",
+			"--seed=answer",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		var expectedError = param.InvalidSeedError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for soup error", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=random",
+			"--symmetry=C3",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		var expectedError = random.UnknownSymmetryError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return soup stream of the same options as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=random",
+			"--width=20",
+			"--height=10",
+			"--density=0.3",
+			"--seed=42",
+			"--symmetry=D2_+2",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		expectedSoupStream, _ := random.New(20, 10, 0.3, 42, "D2_+2")
+		expectedSoup, _ := expectedSoupStream.Read()
+
+		actualSoup, actualError := parameter.GetReader().Read()
+
+		assert.Nil(t, actualError)
+		assert.EqualValues(t, expectedSoup, actualSoup)
+	})
+
+	t.Run("should use default soup options", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=random",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+
+		actualSoupStream := parameter.GetReader().(*random.SoupStream)
+
+		assert.Equal(t, 16, actualSoupStream.GetWidth())
+		assert.Equal(t, 16, actualSoupStream.GetHeight())
+		assert.Equal(t, random.NoSymmetry, actualSoupStream.GetSymmetry())
+	})
+}
+
+func TestGetReader(t *testing.T) {
+	t.Run("should return the same reader as parameter", func(t *testing.T) {
+		var path string = "./input.cell"
+		var args []string = []string{
+			"--inputtype=file",
+			fmt.Sprintf("--inputpath=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		fileStream, _ := file.New(path)
+		var expectedReader io.Reader = fileStream
+
+		actualReader := parameter.GetReader()
+
+		assert.Equal(t, reflect.TypeOf(expectedReader), reflect.TypeOf(actualReader))
+	})
+}
+
+func TestGetReaderByExtension(t *testing.T) {
+	t.Run("should return run length encoded reader for rle input", func(t *testing.T) {
+		var path string = "./input.rle"
+		var args []string = []string{
+			"--inputtype=file",
+			fmt.Sprintf("--inputpath=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		rleStream, _ := rle.New(path)
+		var expectedReader io.Reader = rleStream
+
+		actualReader := parameter.GetReader()
+
+		assert.Equal(t, reflect.TypeOf(expectedReader), reflect.TypeOf(actualReader))
+	})
+}
+
+func saveComposition(t *testing.T, content string) (string, func()) {
+	directory, err := ioutil.TempDir("", "param")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(directory, "input.json")
+	if err = ioutil.WriteFile(path, []byte(content), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(directory) }
+}
+
+func TestGetReaderOfComposition(t *testing.T) {
+	t.Run("should return composition reader for json input", func(t *testing.T) {
+		path, cleanUp := saveComposition(t, `{"patterns": [{"pattern": "glider"}]}`)
+		defer cleanUp()
+		var args []string = []string{
+			"--inputtype=file",
+			fmt.Sprintf("--inputpath=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		compositionStream, _ := composition.New(path)
+		var expectedReader io.Reader = compositionStream
+
+		actualReader := parameter.GetReader()
+
+		assert.Equal(t, reflect.TypeOf(expectedReader), reflect.TypeOf(actualReader))
+		assert.Equal(t, rule.Conway, parameter.GetRule().String())
+	})
+
+	t.Run("should return nil and error for missing composition", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./nonexistent.json",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.True(t, errors.Is(actualError, composition.ErrNotFoundFile))
+	})
+
+	t.Run("should return rule of composition for no rule", func(t *testing.T) {
+		path, cleanUp := saveComposition(t, `{"rule": "B36/S23", "patterns": [{"pattern": "glider"}]}`)
+		defer cleanUp()
+		var args []string = []string{
+			"--inputtype=file",
+			fmt.Sprintf("--inputpath=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, "B36/S23", actualParam.GetRule().String())
+	})
+
+	t.Run("should accept rule that is the one of composition", func(t *testing.T) {
+		path, cleanUp := saveComposition(t, `{"rule": "B36/S23", "patterns": [{"pattern": "glider"}]}`)
+		defer cleanUp()
+		var args []string = []string{
+			"--inputtype=file",
+			fmt.Sprintf("--inputpath=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--rule=B36/S23",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, "B36/S23", actualParam.GetRule().String())
+	})
+
+	t.Run("should return nil and error for rule conflicting with composition", func(t *testing.T) {
+		path, cleanUp := saveComposition(t, `{"rule": "B36/S23", "patterns": [{"pattern": "glider"}]}`)
+		defer cleanUp()
+		var args []string = []string{
+			"--inputtype=file",
+			fmt.Sprintf("--inputpath=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--rule=B3/S23",
+		}
+		var expectedError = param.RuleConflictError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+}
+
+func TestGetReaderFromLibrary(t *testing.T) {
+	t.Run("should return nil and error for library input with no pattern", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=library",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, param.NoPatternError)
+	})
+
+	t.Run("should return nil and error for unknown pattern", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=library",
+			"--pattern=unknown",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, library.UnknownPatternError)
+	})
+
+	t.Run("should return nil and error for library output type", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=library",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, param.UnknownOutputTypeValueError)
+	})
+
+	t.Run("should return reader of the pattern as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=library",
+			"--pattern=gosper-gun",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+
+		actualGeneration, actualError := parameter.GetReader().Read()
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualGeneration, 9)
+		assert.Len(t, actualGeneration[0], 36)
+	})
+}
+
+type registeredStream struct {
+	options string
+}
+
+func (stream *registeredStream) Read() ([][]bool, error) {
+	return [][]bool{{true}}, nil
+}
+
+func (stream *registeredStream) Write(generation [][]bool) error {
+	return nil
+}
+
+func TestGetReaderFromRegistry(t *testing.T) {
+	io.RegisterReader("registered-source", func(options string) (io.Reader, error) {
+		return &registeredStream{options: options}, nil
+	})
+	io.RegisterReader("failing-source", func(options string) (io.Reader, error) {
+		return nil, errors.New("source is unreachable")
+	})
+
+	t.Run("should return reader of registered input type made with its options", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=registered-source",
+			"--inputoptions=host=localhost,port=8080",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		var expectedReader io.Reader = &registeredStream{options: "host=localhost,port=8080"}
+
+		parameter, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedReader, parameter.GetReader())
+	})
+
+	t.Run("should return nil and error of the factory of registered input type", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=failing-source",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, "source is unreachable")
+	})
+
+	t.Run("should return nil and error for input type registered as writer only", func(t *testing.T) {
+		io.RegisterWriter("registered-writer-only", func(options string) (io.Writer, error) {
+			return &registeredStream{options: options}, nil
+		})
+		var args []string = []string{
+			"--inputtype=registered-writer-only",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, param.UnknownInputTypeValueError)
+	})
+
+	t.Run("should return nil and error for resume with input options", func(t *testing.T) {
+		var args []string = []string{
+			"--resume=./checkpoint",
+			"--inputoptions=host=localhost",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, param.ResumeConflictError)
+	})
+}
+
+func TestGetWriterFromRegistry(t *testing.T) {
+	io.RegisterWriter("registered-target", func(options string) (io.Writer, error) {
+		return &registeredStream{options: options}, nil
+	})
+
+	t.Run("should return writer of registered output type made with its options", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=registered-target",
+			"--outputoptions=topic=generations",
+			"--generation=10",
+		}
+		var expectedWriter io.Writer = &registeredStream{options: "topic=generations"}
+
+		parameter, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedWriter, parameter.GetWriter())
+	})
+
+	t.Run("should return nil and error for unregistered output type", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=unregistered-target",
+			"--generation=10",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, param.UnknownOutputTypeValueError)
+	})
+}
+
+func TestGetWriter(t *testing.T) {
+	t.Run("should return the same writer as parameter", func(t *testing.T) {
+		var path string = "./output.cell"
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			fmt.Sprintf("--outputpath=%s", path),
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		fileStream, _ := file.New(path)
+		var expectedReader io.Writer = fileStream
+
+		actualReader := parameter.GetWriter()
+
+		assert.Equal(t, reflect.TypeOf(expectedReader), reflect.TypeOf(actualReader))
+	})
+
+	t.Run("should return png writer for png output", func(t *testing.T) {
+		var path string = "./output.png"
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			fmt.Sprintf("--outputpath=%s", path),
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+		pngStream, _ := png.New(path)
+		var expectedWriter io.Writer = pngStream
+
+		actualWriter := parameter.GetWriter()
+
+		assert.Equal(t, reflect.TypeOf(expectedWriter), reflect.TypeOf(actualWriter))
+	})
+}
+
+func TestGetRender(t *testing.T) {
+	t.Run("should return nil and error for unknown render value", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.png",
+			"--generation=10",
+			"--render=color",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, param.UnknownRenderValueError)
+	})
+
+	t.Run("should return nil and error for rendering ages without image output", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--render=age",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, param.NoHeatWriterError)
+	})
+
+	t.Run("should return generation for no render", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		parameter, _ := param.New(args, nil, nil)
+
+		actualRender := parameter.GetRender()
+
+		assert.Equal(t, param.RenderGeneration, actualRender)
+	})
+
+	t.Run("should return the same render as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.png",
+			"--generation=10",
+			"--render=heat",
+		}
+		parameter, _ := param.New(args, nil, nil)
+
+		actualRender := parameter.GetRender()
+
+		assert.Equal(t, param.RenderHeat, actualRender)
+	})
+}
+
+func saveCheckpoint(t *testing.T, generation, numOfGeneration int) string {
+	directory, err := ioutil.TempDir("", "param")
+	if err != nil {
+		t.Fatal(err)
+	}
+	highLife, _ := rule.New("B36/S23")
+	cellState, _ := cell.NewWithRule([][]bool{{true, true, true}}, highLife)
+	runCheckpoint, _ := checkpoint.New(generation, numOfGeneration, cellState)
+	path, err := runCheckpoint.Save(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("should return nil and error for checkpoint interval without directory", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--checkpoint-every=5",
+		}
+		var expectedError = param.NoCheckpointDirError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for checkpoint directory without interval", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--checkpoint-dir=./checkpoint",
+		}
+		var expectedError = param.NoCheckpointEveryError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid checkpoint interval", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--checkpoint-every=0",
+			"--checkpoint-dir=./checkpoint",
+		}
+		var expectedError = param.InvalidCheckpointEveryError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return the same checkpoint interval and directory as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+			"--checkpoint-every=5",
+			"--checkpoint-dir=./checkpoint",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 5, actualParam.GetCheckpointEvery())
+		assert.Equal(t, "./checkpoint", actualParam.GetCheckpointDir())
+		assert.Nil(t, actualParam.GetResumeCheckpoint())
+	})
+}
+
+func TestResume(t *testing.T) {
+	t.Run("should return nil and error for resume with input type", func(t *testing.T) {
+		var args []string = []string{
+			"--resume=./checkpoint.json",
+			"--inputtype=file",
+			"--inputpath=./input.cell",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+		}
+		var expectedError = param.ResumeConflictError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for resume with transform", func(t *testing.T) {
+		var args []string = []string{
+			"--resume=./checkpoint.json",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--transform=rot90",
+		}
+		var expectedError = param.ResumeConflictError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for resume with rule", func(t *testing.T) {
+		var args []string = []string{
+			"--resume=./checkpoint.json",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--rule=B3/S23",
+		}
+		var expectedError = param.ResumeConflictError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for non existent checkpoint", func(t *testing.T) {
+		var args []string = []string{
+			"--resume=./nonexistent.json",
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+		}
+		var expectedError = "./nonexistent.json: " + checkpoint.NotFoundCheckpointError + ": no such file or directory"
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for generation before checkpoint", func(t *testing.T) {
+		path := saveCheckpoint(t, 20, 100)
+		defer os.RemoveAll(filepath.Dir(path))
+		var args []string = []string{
+			fmt.Sprintf("--resume=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=10",
+		}
+		var expectedError = param.ResumeBeyondGenerationError
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should use generation and rule of checkpoint", func(t *testing.T) {
+		path := saveCheckpoint(t, 20, 100)
+		defer os.RemoveAll(filepath.Dir(path))
+		var args []string = []string{
+			fmt.Sprintf("--resume=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.Nil(t, actualParam.GetReader())
+		assert.Equal(t, 100, actualParam.GetNumOfGeneration())
+		assert.Equal(t, "B36/S23", actualParam.GetRule().String())
+		assert.Equal(t, 20, actualParam.GetResumeCheckpoint().GetGeneration())
+	})
+
+	t.Run("should allow extending the number of generation", func(t *testing.T) {
+		path := saveCheckpoint(t, 20, 100)
+		defer os.RemoveAll(filepath.Dir(path))
+		var args []string = []string{
+			fmt.Sprintf("--resume=%s", path),
+			"--outputtype=file",
+			"--outputpath=./output.cell",
+			"--generation=500",
+		}
+
+		actualParam, actualError := param.New(args, nil, nil)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 500, actualParam.GetNumOfGeneration())
 	})
 }
@@ -0,0 +1,40 @@
+package param
+
+import (
+	"github.com/irainia/gameoflife-go/library"
+)
+
+type PatternsParam struct {
+	patterns []*library.Pattern
+}
+
+// GetPatterns returns the pattern asked for, or every pattern in the
+// library when none is.
+func (parameter *PatternsParam) GetPatterns() []*library.Pattern {
+	return parameter.patterns
+}
+
+func NewPatterns(args []string) (*PatternsParam, error) {
+	mappedArgs, err := mapCommandArgs(args, patternName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := library.GetNames()
+	if name, isProvided := mappedArgs[patternName]; isProvided {
+		names = []string{name}
+	}
+
+	patterns := make([]*library.Pattern, len(names))
+	for i, name := range names {
+		patterns[i], err = library.Get(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parameter = PatternsParam{
+		patterns: patterns,
+	}
+	return &parameter, nil
+}
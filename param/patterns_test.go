@@ -0,0 +1,60 @@
+package param_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/library"
+	"github.com/irainia/gameoflife-go/param"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPatterns(t *testing.T) {
+	t.Run("should return every pattern for no args", func(t *testing.T) {
+		var expectedNames = library.GetNames()
+
+		actualParam, actualError := param.NewPatterns([]string{})
+
+		assert.Nil(t, actualError)
+		actualPatterns := actualParam.GetPatterns()
+		assert.Len(t, actualPatterns, len(expectedNames))
+		for i, pattern := range actualPatterns {
+			assert.Equal(t, expectedNames[i], pattern.GetName())
+		}
+	})
+
+	t.Run("should return the same pattern as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--pattern=acorn",
+		}
+
+		actualParam, actualError := param.NewPatterns(args)
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualParam.GetPatterns(), 1)
+		assert.Equal(t, "acorn", actualParam.GetPatterns()[0].GetName())
+	})
+
+	t.Run("should return nil and error for unknown pattern", func(t *testing.T) {
+		var args []string = []string{
+			"--pattern=unknown",
+		}
+		var expectedError = library.UnknownPatternError
+
+		actualParam, actualError := param.NewPatterns(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for unknown argument", func(t *testing.T) {
+		var args []string = []string{
+			"--generation=1",
+		}
+		var expectedError = param.UnknownArgumentError
+
+		actualParam, actualError := param.NewPatterns(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+}
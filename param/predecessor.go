@@ -0,0 +1,139 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	InvalidMarginError     = "invalid margin (should be whole number)"
+	InvalidMaxResultsError = "invalid max results (should be whole number more than zero)"
+)
+
+var (
+	ErrInvalidMargin     = errors.New(InvalidMarginError)
+	ErrInvalidMaxResults = errors.New(InvalidMaxResultsError)
+)
+
+const (
+	margin     = "--margin"
+	maxResults = "--max-results"
+
+	defaultMargin     = "1"
+	defaultMaxResults = "1"
+
+	resultSeparator = "-"
+)
+
+type PredecessorParam struct {
+	margin     int
+	maxResults int
+	rule       *rule.Rule
+	timeout    time.Duration
+
+	readStream   io.Reader
+	writeStreams []io.Writer
+}
+
+func (parameter *PredecessorParam) GetMargin() int {
+	return parameter.margin
+}
+
+func (parameter *PredecessorParam) GetMaxResults() int {
+	return parameter.maxResults
+}
+
+func (parameter *PredecessorParam) GetRule() *rule.Rule {
+	return parameter.rule
+}
+
+func (parameter *PredecessorParam) GetTimeout() time.Duration {
+	return parameter.timeout
+}
+
+func (parameter *PredecessorParam) GetReader() io.Reader {
+	return parameter.readStream
+}
+
+// GetWriters returns a writer for each result, the first writing to the
+// output path and the next ones to it numbered, e.g. out-2.cell.
+func (parameter *PredecessorParam) GetWriters() []io.Writer {
+	writers := make([]io.Writer, len(parameter.writeStreams))
+	copy(writers, parameter.writeStreams)
+	return writers
+}
+
+func NewPredecessor(args []string) (*PredecessorParam, error) {
+	mappedArgs, err := mapCommandArgs(args, inputPath, outputPath, cellRule, margin, maxResults, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if mappedArgs[inputPath] == emptyArgument {
+		return nil, ErrNoInputPath
+	}
+	if mappedArgs[outputPath] == emptyArgument {
+		return nil, ErrNoOutputPath
+	}
+
+	boxMargin, err := strconv.ParseInt(valueOrDefault(mappedArgs[margin], defaultMargin), baseConvert, bitSizeConvert)
+	if err != nil || boxMargin < 0 {
+		return nil, ErrInvalidMargin
+	}
+	results, err := parsePositive(valueOrDefault(mappedArgs[maxResults], defaultMaxResults), ErrInvalidMaxResults)
+	if err != nil {
+		return nil, err
+	}
+	searchTimeout, err := parseTimeout(mappedArgs[timeout])
+	if err != nil {
+		return nil, err
+	}
+
+	parsedRule := rule.Default()
+	if mappedArgs[cellRule] != emptyArgument {
+		parsedRule, err = rule.Load(mappedArgs[cellRule])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reader, err := newFileReader(mappedArgs[inputPath])
+	if err != nil {
+		return nil, err
+	}
+	writers := make([]io.Writer, results)
+	for i := 0; i < results; i++ {
+		writers[i], err = newFileWriter(numberPath(mappedArgs[outputPath], i))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parameter = PredecessorParam{
+		margin:       int(boxMargin),
+		maxResults:   results,
+		rule:         parsedRule,
+		timeout:      searchTimeout,
+		readStream:   reader,
+		writeStreams: writers,
+	}
+	return &parameter, nil
+}
+
+// numberPath returns path for the first result and path numbered from 2
+// before its extension for the next ones.
+func numberPath(path string, index int) string {
+	if index == 0 {
+		return path
+	}
+
+	extension := filepath.Ext(path)
+	return fmt.Sprintf("%s%s%d%s", strings.TrimSuffix(path, extension), resultSeparator, index+1, extension)
+}
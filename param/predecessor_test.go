@@ -0,0 +1,103 @@
+package param_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/irainia/gameoflife-go/io/file"
+	"github.com/irainia/gameoflife-go/param"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPredecessor(t *testing.T) {
+	t.Run("should return nil and error for no input path", func(t *testing.T) {
+		var expectedError = param.NoInputPathError
+
+		actualParam, actualError := param.NewPredecessor([]string{"--outputpath=out.cell"})
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for no output path", func(t *testing.T) {
+		var expectedError = param.NoOutputPathError
+
+		actualParam, actualError := param.NewPredecessor([]string{"--inputpath=in.cell"})
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid output extension", func(t *testing.T) {
+		var args []string = []string{
+			"--inputpath=in.cell",
+			"--outputpath=out.txt",
+		}
+		var expectedError = file.InvalidExtensionError
+
+		actualParam, actualError := param.NewPredecessor(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid number values", func(t *testing.T) {
+		testCases := []struct {
+			arg           string
+			expectedError string
+		}{
+			{"--margin=-1", param.InvalidMarginError},
+			{"--margin=wide", param.InvalidMarginError},
+			{"--max-results=0", param.InvalidMaxResultsError},
+			{"--timeout=soon", param.InvalidTimeoutError},
+			{"--rule=B3", rule.InvalidNotationError},
+		}
+
+		for _, testCase := range testCases {
+			args := []string{"--inputpath=in.cell", "--outputpath=out.cell", testCase.arg}
+
+			actualParam, actualError := param.NewPredecessor(args)
+
+			assert.Nil(t, actualParam)
+			assert.EqualError(t, actualError, testCase.expectedError)
+		}
+	})
+
+	t.Run("should return defaults for paths only", func(t *testing.T) {
+		var args []string = []string{
+			"--inputpath=in.cell",
+			"--outputpath=out.cell",
+		}
+
+		actualParam, actualError := param.NewPredecessor(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 1, actualParam.GetMargin())
+		assert.Equal(t, 1, actualParam.GetMaxResults())
+		assert.Equal(t, rule.Conway, actualParam.GetRule().String())
+		assert.Equal(t, time.Duration(0), actualParam.GetTimeout())
+		assert.NotNil(t, actualParam.GetReader())
+		assert.Len(t, actualParam.GetWriters(), 1)
+	})
+
+	t.Run("should return a writer for each result", func(t *testing.T) {
+		var args []string = []string{
+			"--inputpath=in.rle",
+			"--outputpath=out.cell",
+			"--margin=0",
+			"--max-results=3",
+			"--rule=B36/S23",
+			"--timeout=10s",
+		}
+
+		actualParam, actualError := param.NewPredecessor(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 0, actualParam.GetMargin())
+		assert.Equal(t, 3, actualParam.GetMaxResults())
+		assert.Equal(t, "B36/S23", actualParam.GetRule().String())
+		assert.Equal(t, 10*time.Second, actualParam.GetTimeout())
+		assert.Len(t, actualParam.GetWriters(), 3)
+	})
+}
@@ -0,0 +1,158 @@
+package param
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/irainia/gameoflife-go/census"
+	"github.com/irainia/gameoflife-go/io/random"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	NoReportPathError         = "no report path provided (use: --report=[report path *.json])"
+	InvalidNumOfSoupsError    = "invalid number of soups (should be whole number more than zero)"
+	InvalidMaxGenerationError = "invalid max generation (should be whole number more than zero)"
+	InvalidMaxPeriodError     = "invalid max period (should be whole number more than zero)"
+	UnknownSearchModeError    = "unknown search mode (use: soup/ship)"
+)
+
+var (
+	ErrNoReportPath         = errors.New(NoReportPathError)
+	ErrInvalidNumOfSoups    = errors.New(InvalidNumOfSoupsError)
+	ErrInvalidMaxGeneration = errors.New(InvalidMaxGenerationError)
+	ErrInvalidMaxPeriod     = errors.New(InvalidMaxPeriodError)
+	ErrUnknownSearchMode    = errors.New(UnknownSearchModeError)
+)
+
+const (
+	numOfSoups    = "--soups"
+	reportPath    = "--report"
+	maxGeneration = "--max-generation"
+	maxPeriod     = "--max-period"
+	searchMode    = "--mode"
+
+	SoupSearchMode = "soup"
+	ShipSearchMode = "ship"
+
+	defaultNumOfSoups    = "100"
+	defaultMaxGeneration = "20000"
+	defaultMaxPeriod     = "30"
+)
+
+type SearchParam struct {
+	numOfSoups    int
+	maxGeneration int
+	maxPeriod     int
+	reportPath    string
+
+	rule       *rule.Rule
+	soupStream *random.SoupStream
+}
+
+func (parameter *SearchParam) GetNumOfSoups() int {
+	return parameter.numOfSoups
+}
+
+func (parameter *SearchParam) GetMaxGeneration() int {
+	return parameter.maxGeneration
+}
+
+func (parameter *SearchParam) GetMaxPeriod() int {
+	return parameter.maxPeriod
+}
+
+func (parameter *SearchParam) GetReportPath() string {
+	return parameter.reportPath
+}
+
+func (parameter *SearchParam) GetRule() *rule.Rule {
+	return parameter.rule
+}
+
+func (parameter *SearchParam) GetSoupStream() *random.SoupStream {
+	return parameter.soupStream
+}
+
+func NewSearch(args []string) (*SearchParam, error) {
+	mappedArgs, err := mapCommandArgs(args,
+		searchMode, numOfSoups, reportPath, maxGeneration, maxPeriod, cellRule,
+		soupWidth, soupHeight, soupDensity, soupSeed, soupSymmetry)
+	if err != nil {
+		return nil, err
+	}
+
+	if valueOrDefault(mappedArgs[searchMode], SoupSearchMode) != SoupSearchMode {
+		return nil, ErrUnknownSearchMode
+	}
+
+	if mappedArgs[reportPath] == emptyArgument {
+		return nil, ErrNoReportPath
+	}
+	if filepath.Ext(mappedArgs[reportPath]) != census.ReportExtension {
+		return nil, census.ErrInvalidExtension
+	}
+
+	soups, err := parsePositive(valueOrDefault(mappedArgs[numOfSoups], defaultNumOfSoups), ErrInvalidNumOfSoups)
+	if err != nil {
+		return nil, err
+	}
+	generations, err := parsePositive(valueOrDefault(mappedArgs[maxGeneration], defaultMaxGeneration), ErrInvalidMaxGeneration)
+	if err != nil {
+		return nil, err
+	}
+	period, err := parsePositive(valueOrDefault(mappedArgs[maxPeriod], defaultMaxPeriod), ErrInvalidMaxPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedRule := rule.Default()
+	if mappedArgs[cellRule] != emptyArgument {
+		parsedRule, err = rule.Load(mappedArgs[cellRule])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	soupStream, err := newSoupStream(mappedArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameter = SearchParam{
+		numOfSoups:    soups,
+		maxGeneration: generations,
+		maxPeriod:     period,
+		reportPath:    mappedArgs[reportPath],
+		rule:          parsedRule,
+		soupStream:    soupStream,
+	}
+	return &parameter, nil
+}
+
+// GetSearchMode returns the mode of a search by args, the soup search when
+// none is given.
+func GetSearchMode(args []string) (string, error) {
+	mode := SoupSearchMode
+	for _, arg := range args {
+		if strings.HasPrefix(arg, searchMode+argumentSeparator) {
+			mode = strings.TrimPrefix(arg, searchMode+argumentSeparator)
+		}
+	}
+	if mode != SoupSearchMode && mode != ShipSearchMode {
+		return "", ErrUnknownSearchMode
+	}
+
+	return mode, nil
+}
+
+func parsePositive(value string, invalidError error) (int, error) {
+	parsed, err := strconv.ParseInt(value, baseConvert, bitSizeConvert)
+	if err != nil || parsed < 1 {
+		return 0, invalidError
+	}
+
+	return int(parsed), nil
+}
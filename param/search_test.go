@@ -0,0 +1,136 @@
+package param_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/census"
+	"github.com/irainia/gameoflife-go/param"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSearch(t *testing.T) {
+	t.Run("should return nil and error for no report path", func(t *testing.T) {
+		var expectedError = param.NoReportPathError
+
+		actualParam, actualError := param.NewSearch([]string{})
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid report extension", func(t *testing.T) {
+		var args []string = []string{
+			"--report=report.csv",
+		}
+		var expectedError = census.InvalidExtensionError
+
+		actualParam, actualError := param.NewSearch(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for unknown argument", func(t *testing.T) {
+		var args []string = []string{
+			"--report=report.json",
+			"--generation=1",
+		}
+		var expectedError = param.UnknownArgumentError
+
+		actualParam, actualError := param.NewSearch(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid number values", func(t *testing.T) {
+		testCases := []struct {
+			arg           string
+			expectedError string
+		}{
+			{"--soups=0", param.InvalidNumOfSoupsError},
+			{"--soups=many", param.InvalidNumOfSoupsError},
+			{"--max-generation=-1", param.InvalidMaxGenerationError},
+			{"--max-period=0", param.InvalidMaxPeriodError},
+			{"--width=wide", param.InvalidWidthError},
+			{"--seed=seed", param.InvalidSeedError},
+		}
+
+		for _, testCase := range testCases {
+			args := []string{"--report=report.json", testCase.arg}
+
+			actualParam, actualError := param.NewSearch(args)
+
+			assert.Nil(t, actualParam)
+			assert.EqualError(t, actualError, testCase.expectedError)
+		}
+	})
+
+	t.Run("should return nil and error for other mode", func(t *testing.T) {
+		var args []string = []string{
+			"--report=report.json",
+			"--mode=ship",
+		}
+		var expectedError = param.UnknownSearchModeError
+
+		actualParam, actualError := param.NewSearch(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid rule", func(t *testing.T) {
+		var args []string = []string{
+			"--report=report.json",
+			"--rule=B3",
+		}
+		var expectedError = rule.InvalidNotationError
+
+		actualParam, actualError := param.NewSearch(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return defaults for report path only", func(t *testing.T) {
+		var args []string = []string{
+			"--report=report.json",
+		}
+
+		actualParam, actualError := param.NewSearch(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 100, actualParam.GetNumOfSoups())
+		assert.Equal(t, 20000, actualParam.GetMaxGeneration())
+		assert.Equal(t, 30, actualParam.GetMaxPeriod())
+		assert.Equal(t, "report.json", actualParam.GetReportPath())
+		assert.Equal(t, rule.Conway, actualParam.GetRule().String())
+		assert.Equal(t, 16, actualParam.GetSoupStream().GetWidth())
+	})
+
+	t.Run("should return the same values as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--report=report.json",
+			"--soups=5",
+			"--max-generation=1000",
+			"--max-period=10",
+			"--rule=B36/S23",
+			"--width=20",
+			"--height=20",
+			"--density=0.4",
+			"--seed=42",
+			"--symmetry=D8_1",
+		}
+
+		actualParam, actualError := param.NewSearch(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 5, actualParam.GetNumOfSoups())
+		assert.Equal(t, 1000, actualParam.GetMaxGeneration())
+		assert.Equal(t, 10, actualParam.GetMaxPeriod())
+		assert.Equal(t, "B36/S23", actualParam.GetRule().String())
+		assert.Equal(t, int64(42), actualParam.GetSoupStream().GetSeed())
+		assert.Equal(t, "D8_1", actualParam.GetSoupStream().GetSymmetry())
+	})
+}
@@ -0,0 +1,47 @@
+package param
+
+import (
+	"errors"
+)
+
+const (
+	EmptyAddressError = "address is empty (use: --address=[host:port])"
+)
+
+var (
+	ErrEmptyAddress = errors.New(EmptyAddressError)
+)
+
+const (
+	address = "--address"
+
+	defaultAddress = "localhost:8080"
+)
+
+type ServeParam struct {
+	address string
+}
+
+func (parameter *ServeParam) GetAddress() string {
+	return parameter.address
+}
+
+func NewServe(args []string) (*ServeParam, error) {
+	mappedArgs, err := mapCommandArgs(args, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serveAddress, isProvided := mappedArgs[address]
+	if !isProvided {
+		serveAddress = defaultAddress
+	}
+	if serveAddress == emptyArgument {
+		return nil, ErrEmptyAddress
+	}
+
+	var parameter = ServeParam{
+		address: serveAddress,
+	}
+	return &parameter, nil
+}
@@ -0,0 +1,67 @@
+package param_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/param"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServe(t *testing.T) {
+	t.Run("should return default address for no args", func(t *testing.T) {
+		var expectedAddress = "localhost:8080"
+
+		actualParam, actualError := param.NewServe([]string{})
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedAddress, actualParam.GetAddress())
+	})
+
+	t.Run("should return nil and error for unknown argument", func(t *testing.T) {
+		var args []string = []string{
+			"--generation=1",
+		}
+		var expectedError = param.UnknownArgumentError
+
+		actualParam, actualError := param.NewServe(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for no separator", func(t *testing.T) {
+		var args []string = []string{
+			"--address",
+		}
+		var expectedError = param.NoSeparatorError
+
+		actualParam, actualError := param.NewServe(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for empty address", func(t *testing.T) {
+		var args []string = []string{
+			"--address=",
+		}
+		var expectedError = param.EmptyAddressError
+
+		actualParam, actualError := param.NewServe(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return the same address as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--address=:9090",
+		}
+		var expectedAddress = ":9090"
+
+		actualParam, actualError := param.NewServe(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedAddress, actualParam.GetAddress())
+	})
+}
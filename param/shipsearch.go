@@ -0,0 +1,132 @@
+package param
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/periodic"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	InvalidPeriodError    = "invalid period (should be whole number more than zero)"
+	PeriodMismatchError   = "period is not a multiple of the period of the velocity (e.g. --velocity=c/2 with --period=4)"
+	InvalidMaxHeightError = "invalid max height (should be whole number more than zero)"
+)
+
+var (
+	ErrInvalidPeriod    = errors.New(InvalidPeriodError)
+	ErrPeriodMismatch   = errors.New(PeriodMismatchError)
+	ErrInvalidMaxHeight = errors.New(InvalidMaxHeightError)
+)
+
+const (
+	period    = "--period"
+	velocity  = "--velocity"
+	maxHeight = "--max-height"
+
+	defaultVelocity  = "0"
+	defaultShipWidth = "6"
+	defaultMaxHeight = "12"
+)
+
+type ShipSearchParam struct {
+	maxResults int
+	timeout    time.Duration
+
+	finder       *periodic.Finder
+	writeStreams []io.Writer
+}
+
+func (parameter *ShipSearchParam) GetMaxResults() int {
+	return parameter.maxResults
+}
+
+func (parameter *ShipSearchParam) GetTimeout() time.Duration {
+	return parameter.timeout
+}
+
+func (parameter *ShipSearchParam) GetFinder() *periodic.Finder {
+	return parameter.finder
+}
+
+// GetWriters returns a writer for each result, the first writing to the
+// output path and the next ones to it numbered, e.g. out-2.cell.
+func (parameter *ShipSearchParam) GetWriters() []io.Writer {
+	writers := make([]io.Writer, len(parameter.writeStreams))
+	copy(writers, parameter.writeStreams)
+	return writers
+}
+
+func NewShipSearch(args []string) (*ShipSearchParam, error) {
+	mappedArgs, err := mapCommandArgs(args,
+		searchMode, outputPath, cellRule, period, velocity, soupWidth, maxHeight, maxResults, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if mappedArgs[searchMode] != ShipSearchMode {
+		return nil, ErrUnknownSearchMode
+	}
+	if mappedArgs[outputPath] == emptyArgument {
+		return nil, ErrNoOutputPath
+	}
+
+	shift, velocityPeriod, err := periodic.ParseVelocity(valueOrDefault(mappedArgs[velocity], defaultVelocity))
+	if err != nil {
+		return nil, err
+	}
+	searchPeriod, err := parsePositive(valueOrDefault(mappedArgs[period], strconv.Itoa(velocityPeriod)), ErrInvalidPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if searchPeriod%velocityPeriod != 0 {
+		return nil, ErrPeriodMismatch
+	}
+	width, err := strconv.ParseInt(valueOrDefault(mappedArgs[soupWidth], defaultShipWidth), baseConvert, bitSizeConvert)
+	if err != nil {
+		return nil, ErrInvalidWidth
+	}
+	height, err := parsePositive(valueOrDefault(mappedArgs[maxHeight], defaultMaxHeight), ErrInvalidMaxHeight)
+	if err != nil {
+		return nil, err
+	}
+	results, err := parsePositive(valueOrDefault(mappedArgs[maxResults], defaultMaxResults), ErrInvalidMaxResults)
+	if err != nil {
+		return nil, err
+	}
+	searchTimeout, err := parseTimeout(mappedArgs[timeout])
+	if err != nil {
+		return nil, err
+	}
+
+	parsedRule := rule.Default()
+	if mappedArgs[cellRule] != emptyArgument {
+		parsedRule, err = rule.Load(mappedArgs[cellRule])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	finder, err := periodic.New(parsedRule, searchPeriod, shift*searchPeriod/velocityPeriod, int(width), height)
+	if err != nil {
+		return nil, err
+	}
+	writers := make([]io.Writer, results)
+	for i := 0; i < results; i++ {
+		writers[i], err = newFileWriter(numberPath(mappedArgs[outputPath], i))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parameter = ShipSearchParam{
+		maxResults:   results,
+		timeout:      searchTimeout,
+		finder:       finder,
+		writeStreams: writers,
+	}
+	return &parameter, nil
+}
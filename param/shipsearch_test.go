@@ -0,0 +1,135 @@
+package param_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/irainia/gameoflife-go/io/file"
+	"github.com/irainia/gameoflife-go/param"
+	"github.com/irainia/gameoflife-go/periodic"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSearchMode(t *testing.T) {
+	t.Run("should return error for unknown mode", func(t *testing.T) {
+		var expectedError = param.UnknownSearchModeError
+
+		actualMode, actualError := param.GetSearchMode([]string{"--mode=glider"})
+
+		assert.Equal(t, "", actualMode)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return mode of args", func(t *testing.T) {
+		testCases := []struct {
+			args         []string
+			expectedMode string
+		}{
+			{[]string{}, param.SoupSearchMode},
+			{[]string{"--report=report.json"}, param.SoupSearchMode},
+			{[]string{"--mode=soup", "--soups=5"}, param.SoupSearchMode},
+			{[]string{"--velocity=c/2", "--mode=ship"}, param.ShipSearchMode},
+		}
+
+		for _, testCase := range testCases {
+			actualMode, actualError := param.GetSearchMode(testCase.args)
+
+			assert.Nil(t, actualError)
+			assert.Equal(t, testCase.expectedMode, actualMode)
+		}
+	})
+}
+
+func TestNewShipSearch(t *testing.T) {
+	t.Run("should return nil and error for other mode", func(t *testing.T) {
+		var expectedError = param.UnknownSearchModeError
+
+		actualParam, actualError := param.NewShipSearch([]string{"--outputpath=out.cell"})
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for no output path", func(t *testing.T) {
+		var expectedError = param.NoOutputPathError
+
+		actualParam, actualError := param.NewShipSearch([]string{"--mode=ship"})
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid values", func(t *testing.T) {
+		testCases := []struct {
+			arg           string
+			expectedError string
+		}{
+			{"--outputpath=out.txt", file.InvalidExtensionError},
+			{"--velocity=c", periodic.InvalidVelocityError},
+			{"--period=0", param.InvalidPeriodError},
+			{"--period=3", param.PeriodMismatchError},
+			{"--width=wide", param.InvalidWidthError},
+			{"--width=17", periodic.InvalidWidthError},
+			{"--max-height=0", param.InvalidMaxHeightError},
+			{"--max-results=0", param.InvalidMaxResultsError},
+			{"--timeout=soon", param.InvalidTimeoutError},
+			{"--rule=B3", rule.InvalidNotationError},
+			{"--report=report.json", param.UnknownArgumentError},
+		}
+
+		for _, testCase := range testCases {
+			args := []string{"--mode=ship", "--outputpath=out.cell", "--velocity=c/2", testCase.arg}
+
+			actualParam, actualError := param.NewShipSearch(args)
+
+			assert.Nil(t, actualParam)
+			assert.EqualError(t, actualError, testCase.expectedError)
+		}
+	})
+
+	t.Run("should return defaults for mode and output path only", func(t *testing.T) {
+		var args []string = []string{
+			"--mode=ship",
+			"--outputpath=out.cell",
+		}
+
+		actualParam, actualError := param.NewShipSearch(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 1, actualParam.GetMaxResults())
+		assert.Equal(t, time.Duration(0), actualParam.GetTimeout())
+		assert.Equal(t, rule.Conway, actualParam.GetFinder().GetRule().String())
+		assert.Equal(t, 1, actualParam.GetFinder().GetPeriod())
+		assert.Equal(t, 0, actualParam.GetFinder().GetShift())
+		assert.Equal(t, 6, actualParam.GetFinder().GetWidth())
+		assert.Equal(t, 12, actualParam.GetFinder().GetMaxHeight())
+		assert.Len(t, actualParam.GetWriters(), 1)
+	})
+
+	t.Run("should return the same values as parameter", func(t *testing.T) {
+		var args []string = []string{
+			"--mode=ship",
+			"--outputpath=out.cell",
+			"--rule=B34/S34",
+			"--velocity=c/2",
+			"--period=4",
+			"--width=5",
+			"--max-height=8",
+			"--max-results=2",
+			"--timeout=1m",
+		}
+
+		actualParam, actualError := param.NewShipSearch(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 2, actualParam.GetMaxResults())
+		assert.Equal(t, time.Minute, actualParam.GetTimeout())
+		assert.Equal(t, "B34/S34", actualParam.GetFinder().GetRule().String())
+		assert.Equal(t, 4, actualParam.GetFinder().GetPeriod())
+		assert.Equal(t, 2, actualParam.GetFinder().GetShift())
+		assert.Equal(t, 5, actualParam.GetFinder().GetWidth())
+		assert.Equal(t, 8, actualParam.GetFinder().GetMaxHeight())
+		assert.Len(t, actualParam.GetWriters(), 2)
+	})
+}
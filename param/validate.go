@@ -0,0 +1,57 @@
+package param
+
+import (
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+type ValidateParam struct {
+	path string
+	rule *rule.Rule
+
+	readStream io.Reader
+}
+
+func (parameter *ValidateParam) GetPath() string {
+	return parameter.path
+}
+
+func (parameter *ValidateParam) GetRule() *rule.Rule {
+	return parameter.rule
+}
+
+func (parameter *ValidateParam) GetReader() io.Reader {
+	return parameter.readStream
+}
+
+func NewValidate(args []string) (*ValidateParam, error) {
+	mappedArgs, err := mapCommandArgs(args, inputPath, cellRule)
+	if err != nil {
+		return nil, err
+	}
+
+	if mappedArgs[inputPath] == emptyArgument {
+		return nil, ErrNoInputPath
+	}
+
+	parsedRule := rule.Default()
+	if mappedArgs[cellRule] != emptyArgument {
+		parsedRule, err = rule.Load(mappedArgs[cellRule])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reader, err := newFileReader(mappedArgs[inputPath])
+	if err != nil {
+		return nil, err
+	}
+
+	var parameter = ValidateParam{
+		path: mappedArgs[inputPath],
+		rule: parsedRule,
+
+		readStream: reader,
+	}
+	return &parameter, nil
+}
@@ -0,0 +1,90 @@
+package param_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/rle"
+	"github.com/irainia/gameoflife-go/param"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewValidate(t *testing.T) {
+	t.Run("should return nil and error for no input path", func(t *testing.T) {
+		var expectedError = param.NoInputPathError
+
+		actualParam, actualError := param.NewValidate([]string{})
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for unknown argument", func(t *testing.T) {
+		var args []string = []string{
+			"--inputpath=./input.cell",
+			"--generation=1",
+		}
+		var expectedError = param.UnknownArgumentError
+
+		actualParam, actualError := param.NewValidate(args)
+
+		assert.Nil(t, actualParam)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should keep separators within the value", func(t *testing.T) {
+		var args []string = []string{
+			"--inputpath=./input=1.cell",
+		}
+		var expectedPath = "./input=1.cell"
+
+		actualParam, actualError := param.NewValidate(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedPath, actualParam.GetPath())
+	})
+
+	t.Run("should return nil and error for invalid rule", func(t *testing.T) {
+		var args []string = []string{
+			"--inputpath=./input.cell",
+			"--rule=B3/S23/X",
+		}
+
+		actualParam, actualError := param.NewValidate(args)
+
+		assert.Nil(t, actualParam)
+		assert.NotNil(t, actualError)
+	})
+
+	t.Run("should return conway rule for no rule", func(t *testing.T) {
+		var args []string = []string{
+			"--inputpath=./input.cell",
+		}
+
+		actualParam, actualError := param.NewValidate(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, rule.Default().String(), actualParam.GetRule().String())
+		assert.Equal(t, "./input.cell", actualParam.GetPath())
+	})
+
+	t.Run("should return validator reader of the path", func(t *testing.T) {
+		var path = "./input.rle"
+		var args []string = []string{
+			"--inputpath=" + path,
+			"--rule=WireWorld",
+		}
+		rleStream, _ := rle.New(path)
+		var expectedReader io.Reader = rleStream
+
+		actualParam, actualError := param.NewValidate(args)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, reflect.TypeOf(expectedReader), reflect.TypeOf(actualParam.GetReader()))
+		_, isValidator := actualParam.GetReader().(io.Validator)
+		assert.True(t, isValidator)
+		assert.Equal(t, 4, actualParam.GetRule().GetNumOfStates())
+	})
+}
@@ -0,0 +1,408 @@
+package periodic
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/irainia/gameoflife-go/apgcode"
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	MaxWidth = 16
+)
+
+const (
+	NilRuleError            = "rule passed is nil"
+	MultiStateRuleError     = "rule with more than two states is not supported"
+	UnsupportedRadiusError  = "rule with a neighborhood beyond the adjacent cells is not supported"
+	InvalidPeriodError      = "period is less than one (should be at least 1)"
+	InvalidShiftError       = "shift is out of range (should be at least 0 and less than the period)"
+	InvalidWidthError       = "width is out of range (should be 1 to 16)"
+	InvalidMaxHeightError   = "max height is less than one (should be at least 1)"
+	InvalidMaxResultsError  = "max results is less than one (should be at least 1)"
+	InvalidVelocityError    = "velocity is invalid (use: 0 for oscillators or [shift]c/[period] for orthogonal spaceships, e.g. c/4 or 2c/5)"
+	UnverifiedSolutionError = "pattern found does not repeat with the period and shift searched"
+)
+
+var (
+	ErrNilRule            = errors.New(NilRuleError)
+	ErrMultiStateRule     = errors.New(MultiStateRuleError)
+	ErrUnsupportedRadius  = errors.New(UnsupportedRadiusError)
+	ErrInvalidPeriod      = errors.New(InvalidPeriodError)
+	ErrInvalidShift       = errors.New(InvalidShiftError)
+	ErrInvalidWidth       = errors.New(InvalidWidthError)
+	ErrInvalidMaxHeight   = errors.New(InvalidMaxHeightError)
+	ErrInvalidMaxResults  = errors.New(InvalidMaxResultsError)
+	ErrInvalidVelocity    = errors.New(InvalidVelocityError)
+	ErrUnverifiedSolution = errors.New(UnverifiedSolutionError)
+)
+
+const (
+	speedOfLight      = "c"
+	velocitySeparator = "/"
+	zeroVelocity      = "0"
+
+	nodesPerPoll = 1 << 12
+)
+
+// Finder searches for patterns that repeat every period generations moved
+// up by shift rows, oscillators when shift is zero and spaceships
+// otherwise, within a strip of width columns.
+//
+// The search is depth first over partial patterns, as gfind does: the rows
+// of every phase are added one at a time, row r of phase t after row r of
+// the phases before it, and each row is only kept when the rows above it
+// evolve into the rows of the next phase. A row is then either forced by
+// the rows it evolves from or chosen column by column among the rows that
+// evolve the phase before it correctly.
+type Finder struct {
+	rule      *rule.Rule
+	period    int
+	shift     int
+	width     int
+	maxHeight int
+
+	nextStates []bool
+}
+
+type searchState struct {
+	ctx        context.Context
+	maxResults int
+	numOfNodes int
+	rows       []uint32
+	codes      map[string]bool
+	results    []*cell.CellState
+}
+
+func (finder *Finder) GetRule() *rule.Rule {
+	return finder.rule
+}
+
+func (finder *Finder) GetPeriod() int {
+	return finder.period
+}
+
+func (finder *Finder) GetShift() int {
+	return finder.shift
+}
+
+func (finder *Finder) GetWidth() int {
+	return finder.width
+}
+
+func (finder *Finder) GetMaxHeight() int {
+	return finder.maxHeight
+}
+
+// Find returns up to maxResults patterns found, each a different object in
+// the phase it was found in, verified by stepping it through a period. It
+// stops with the error of ctx when it is done first, returning the patterns
+// found until then.
+func (finder *Finder) Find(ctx context.Context, maxResults int) ([]*cell.CellState, error) {
+	if maxResults < 1 {
+		return nil, ErrInvalidMaxResults
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	state := searchState{
+		ctx:        ctx,
+		maxResults: maxResults,
+		rows:       make([]uint32, 0, finder.period*finder.maxHeight),
+		codes:      make(map[string]bool),
+		results:    make([]*cell.CellState, 0),
+	}
+	err := finder.extend(&state)
+	return state.results, err
+}
+
+// extend adds every row possible next and searches on from each, until
+// enough results are found.
+func (finder *Finder) extend(state *searchState) error {
+	state.numOfNodes++
+	if state.numOfNodes%nodesPerPoll == 0 {
+		select {
+		case <-state.ctx.Done():
+			return state.ctx.Err()
+		default:
+		}
+	}
+
+	index := len(state.rows)
+	phase, row := index%finder.period, index/finder.period
+	if row >= finder.maxHeight+finder.shift+2 {
+		return nil
+	}
+
+	for _, candidate := range finder.makeCandidates(state.rows, phase, row) {
+		// below max height only the blank rows ending the pattern are added
+		if row >= finder.maxHeight && candidate != 0 {
+			continue
+		}
+		state.rows = append(state.rows, candidate)
+		isDone, err := finder.check(state)
+		if err == nil && !isDone {
+			err = finder.extend(state)
+		}
+		state.rows = state.rows[:index]
+		if err != nil {
+			return err
+		}
+		if len(state.results) >= state.maxResults {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// makeCandidates returns the rows that can be row of phase next.
+func (finder *Finder) makeCandidates(rows []uint32, phase, row int) []uint32 {
+	above, centre := finder.getRow(rows, phase, row-2), finder.getRow(rows, phase, row-1)
+	targetPhase, targetRow := phase+1, row-1
+	if targetPhase == finder.period {
+		targetPhase, targetRow = 0, row-1+finder.shift
+	}
+	isTargetKnown := targetRow*finder.period+targetPhase < len(rows)
+
+	// the first phase of a spaceship moving two rows or more a period is
+	// the last phase evolved and shifted, and is known before it is added
+	if phase == 0 && finder.shift > 1 {
+		lastPhase, lastRow := finder.period-1, row-finder.shift
+		forced, isValid := finder.evolve(
+			finder.getRow(rows, lastPhase, lastRow-1),
+			finder.getRow(rows, lastPhase, lastRow),
+			finder.getRow(rows, lastPhase, lastRow+1))
+		if !isValid {
+			return nil
+		}
+		if isTargetKnown {
+			next, isValid := finder.evolve(above, centre, forced)
+			if !isValid || next != finder.getRow(rows, targetPhase, targetRow) {
+				return nil
+			}
+		}
+		return []uint32{forced}
+	}
+
+	if !isTargetKnown {
+		candidates := make([]uint32, 1<<uint(finder.width))
+		for i := range candidates {
+			candidates[i] = uint32(i)
+		}
+		return candidates
+	}
+
+	candidates := make([]uint32, 0)
+	target := finder.getRow(rows, targetPhase, targetRow)
+	finder.collectBelow(above, centre, target, 0, 0, &candidates)
+	return candidates
+}
+
+// collectBelow chooses the row below centre column by column from col on,
+// keeping the choices where each column whose neighborhood is complete
+// evolves into target.
+func (finder *Finder) collectBelow(above, centre, target, below uint32, col int, candidates *[]uint32) {
+	if col == finder.width {
+		if finder.isColumnValid(above, centre, below, target, finder.width-1) &&
+			finder.isColumnValid(above, centre, below, target, finder.width) {
+			*candidates = append(*candidates, below)
+		}
+		return
+	}
+
+	for bit := uint32(0); bit <= 1; bit++ {
+		next := below | bit<<uint(col)
+		if finder.isColumnValid(above, centre, next, target, col-1) {
+			finder.collectBelow(above, centre, target, next, col+1, candidates)
+		}
+	}
+}
+
+func (finder *Finder) isColumnValid(above, centre, below, target uint32, col int) bool {
+	return finder.nextStates[finder.getNeighborhood(above, centre, below, col)] == (getCell(target, col, finder.width) == 1)
+}
+
+// evolve returns the next state of centre, and whether it stays within the
+// width.
+func (finder *Finder) evolve(above, centre, below uint32) (uint32, bool) {
+	next := uint32(0)
+	for col := -1; col <= finder.width; col++ {
+		if !finder.nextStates[finder.getNeighborhood(above, centre, below, col)] {
+			continue
+		}
+		if col < 0 || col == finder.width {
+			return 0, false
+		}
+		next |= 1 << uint(col)
+	}
+	return next, true
+}
+
+func (finder *Finder) getNeighborhood(above, centre, below uint32, col int) int {
+	neighborhood := 0
+	for i, row := range [cell.NeighborhoodSide]uint32{above, centre, below} {
+		for j := 0; j < cell.NeighborhoodSide; j++ {
+			neighborhood |= int(getCell(row, col+j-1, finder.width)) << uint(i*cell.NeighborhoodSide+j)
+		}
+	}
+	return neighborhood
+}
+
+// check tells whether the rows added so far end the pattern, and adds the
+// pattern to the results when it is a new one.
+func (finder *Finder) check(state *searchState) (bool, error) {
+	index := len(state.rows) - 1
+	if index == finder.period-1 && isBlank(state.rows) {
+		// a pattern starting lower is found starting at the first row
+		return true, nil
+	}
+
+	// the rows of the last shift+2 rows of every phase being blank, every
+	// rule left to check is between blank rows
+	window := (finder.shift + 2) * finder.period
+	if len(state.rows) < window || !isBlank(state.rows[len(state.rows)-window:]) {
+		return false, nil
+	}
+	if isBlank(state.rows) {
+		return true, nil
+	}
+
+	generation := make([][]bool, len(state.rows)/finder.period+1)
+	for i := range generation {
+		generation[i] = make([]bool, finder.width)
+		for j := 0; j < finder.width; j++ {
+			generation[i][j] = getCell(finder.getRow(state.rows, 0, i), j, finder.width) == 1
+		}
+	}
+	cellState, err := cell.NewWithRule(generation, finder.rule)
+	if err != nil {
+		return true, err
+	}
+
+	period, rowShift, colShift, err := apgcode.Classify(cellState, finder.period)
+	if err != nil {
+		return true, ErrUnverifiedSolution
+	}
+	if period < finder.period {
+		return true, nil
+	}
+	if rowShift != -finder.shift || colShift != 0 {
+		return true, ErrUnverifiedSolution
+	}
+
+	code, err := apgcode.Encode(cellState, finder.period)
+	if err != nil {
+		return true, err
+	}
+	if !state.codes[code] {
+		state.codes[code] = true
+		state.results = append(state.results, cellState)
+	}
+	return true, nil
+}
+
+// getRow returns row of phase, blank above the pattern and below the rows
+// added so far.
+func (finder *Finder) getRow(rows []uint32, phase, row int) uint32 {
+	index := row*finder.period + phase
+	if row < 0 || index >= len(rows) {
+		return 0
+	}
+	return rows[index]
+}
+
+func getCell(row uint32, col, width int) uint32 {
+	if col < 0 || col >= width {
+		return 0
+	}
+	return row >> uint(col) & 1
+}
+
+func isBlank(rows []uint32) bool {
+	for _, row := range rows {
+		if row != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseVelocity returns the rows moved and the generations taken by
+// velocity, e.g. 2 and 5 for 2c/5 and 0 and 1 for 0.
+func ParseVelocity(velocity string) (int, int, error) {
+	if velocity == zeroVelocity {
+		return 0, 1, nil
+	}
+
+	parts := strings.Split(velocity, velocitySeparator)
+	if len(parts) > 2 || !strings.HasSuffix(parts[0], speedOfLight) {
+		return 0, 0, ErrInvalidVelocity
+	}
+
+	shift, period := 1, 1
+	var err error
+	if numerator := strings.TrimSuffix(parts[0], speedOfLight); numerator != "" {
+		shift, err = strconv.Atoi(numerator)
+		if err != nil || shift < 1 {
+			return 0, 0, ErrInvalidVelocity
+		}
+	}
+	if len(parts) == 2 {
+		period, err = strconv.Atoi(parts[1])
+		if err != nil || period < 1 {
+			return 0, 0, ErrInvalidVelocity
+		}
+	}
+	if shift >= period {
+		return 0, 0, ErrInvalidVelocity
+	}
+
+	return shift, period, nil
+}
+
+func New(cellRule *rule.Rule, period, shift, width, maxHeight int) (*Finder, error) {
+	if cellRule == nil {
+		return nil, ErrNilRule
+	}
+	if cellRule.GetNumOfStates() > rule.MinNumOfStates {
+		return nil, ErrMultiStateRule
+	}
+	if cellRule.GetRadius() > 1 {
+		return nil, ErrUnsupportedRadius
+	}
+	if period < 1 {
+		return nil, ErrInvalidPeriod
+	}
+	if shift < 0 || shift >= period {
+		return nil, ErrInvalidShift
+	}
+	if width < 1 || width > MaxWidth {
+		return nil, ErrInvalidWidth
+	}
+	if maxHeight < 1 {
+		return nil, ErrInvalidMaxHeight
+	}
+
+	nextStates, err := cell.GetNextStates(cellRule)
+	if err != nil {
+		return nil, err
+	}
+
+	var finder = Finder{
+		rule:       cellRule,
+		period:     period,
+		shift:      shift,
+		width:      width,
+		maxHeight:  maxHeight,
+		nextStates: nextStates,
+	}
+	return &finder, nil
+}
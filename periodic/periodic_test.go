@@ -0,0 +1,207 @@
+package periodic_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/apgcode"
+	"github.com/irainia/gameoflife-go/periodic"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+// checkedContext is done once it has been checked numOfChecks times, so a
+// search stops after a known number of steps.
+type checkedContext struct {
+	context.Context
+	numOfChecks int
+	done        chan struct{}
+}
+
+func newCheckedContext(numOfChecks int) *checkedContext {
+	return &checkedContext{
+		Context:     context.Background(),
+		numOfChecks: numOfChecks,
+		done:        make(chan struct{}),
+	}
+}
+
+func (ctx *checkedContext) Done() <-chan struct{} {
+	if ctx.numOfChecks == 0 && ctx.Err() == nil {
+		close(ctx.done)
+	}
+	ctx.numOfChecks--
+	return ctx.done
+}
+
+func (ctx *checkedContext) Err() error {
+	select {
+	case <-ctx.done:
+		return context.Canceled
+	default:
+		return nil
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for invalid arguments", func(t *testing.T) {
+		starWars, _ := rule.New("B2/S345/C4")
+		bosco, _ := rule.New("R5,C0,M1,S34..58,B34..45,NM")
+		testCases := []struct {
+			rule          *rule.Rule
+			period        int
+			shift         int
+			width         int
+			maxHeight     int
+			expectedError string
+		}{
+			{nil, 2, 0, 5, 5, periodic.NilRuleError},
+			{starWars, 2, 0, 5, 5, periodic.MultiStateRuleError},
+			{bosco, 2, 0, 5, 5, periodic.UnsupportedRadiusError},
+			{rule.Default(), 0, 0, 5, 5, periodic.InvalidPeriodError},
+			{rule.Default(), 2, 2, 5, 5, periodic.InvalidShiftError},
+			{rule.Default(), 2, -1, 5, 5, periodic.InvalidShiftError},
+			{rule.Default(), 2, 0, 0, 5, periodic.InvalidWidthError},
+			{rule.Default(), 2, 0, periodic.MaxWidth + 1, 5, periodic.InvalidWidthError},
+			{rule.Default(), 2, 0, 5, 0, periodic.InvalidMaxHeightError},
+		}
+
+		for _, testCase := range testCases {
+			actualFinder, actualError := periodic.New(testCase.rule, testCase.period, testCase.shift, testCase.width, testCase.maxHeight)
+
+			assert.Nil(t, actualFinder)
+			assert.EqualError(t, actualError, testCase.expectedError)
+		}
+	})
+
+	t.Run("should return finder with the same values as parameter", func(t *testing.T) {
+		actualFinder, actualError := periodic.New(rule.Default(), 4, 2, 5, 6)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, rule.Conway, actualFinder.GetRule().String())
+		assert.Equal(t, 4, actualFinder.GetPeriod())
+		assert.Equal(t, 2, actualFinder.GetShift())
+		assert.Equal(t, 5, actualFinder.GetWidth())
+		assert.Equal(t, 6, actualFinder.GetMaxHeight())
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("should return nil and error for invalid max results", func(t *testing.T) {
+		finder, _ := periodic.New(rule.Default(), 2, 0, 3, 3)
+
+		actualResults, actualError := finder.Find(context.Background(), 0)
+
+		assert.Nil(t, actualResults)
+		assert.EqualError(t, actualError, periodic.InvalidMaxResultsError)
+	})
+
+	t.Run("should return the oscillators of the period within the width", func(t *testing.T) {
+		finder, _ := periodic.New(rule.Default(), 2, 0, 4, 4)
+		var expectedCodes = []string{"xp2_7", "xp2_7e", "xp2_2a54", "xp2_318c"}
+
+		actualResults, actualError := finder.Find(context.Background(), 10)
+
+		assert.Nil(t, actualError)
+		actualCodes := make([]string, len(actualResults))
+		for i, result := range actualResults {
+			actualCodes[i], _ = apgcode.Encode(result, 2)
+		}
+		assert.ElementsMatch(t, expectedCodes, actualCodes)
+	})
+
+	t.Run("should return spaceships moving up by the shift", func(t *testing.T) {
+		testCases := []struct {
+			notation     string
+			period       int
+			shift        int
+			expectedCode string
+		}{
+			{rule.Conway, 4, 2, "xq4_6frc"},
+			{"B34/S34", 3, 1, "xq3_6f"},
+		}
+
+		for _, testCase := range testCases {
+			cellRule, _ := rule.New(testCase.notation)
+			finder, _ := periodic.New(cellRule, testCase.period, testCase.shift, 5, 6)
+
+			actualResults, actualError := finder.Find(context.Background(), 1)
+
+			assert.Nil(t, actualError)
+			assert.Len(t, actualResults, 1)
+			actualPeriod, actualRowShift, actualColShift, _ := apgcode.Classify(actualResults[0], testCase.period)
+			assert.Equal(t, testCase.period, actualPeriod)
+			assert.Equal(t, -testCase.shift, actualRowShift)
+			assert.Equal(t, 0, actualColShift)
+			actualCode, _ := apgcode.Encode(actualResults[0], testCase.period)
+			assert.Equal(t, testCase.expectedCode, actualCode)
+		}
+	})
+
+	t.Run("should return no result for patterns of a shorter period only", func(t *testing.T) {
+		finder, _ := periodic.New(rule.Default(), 2, 0, 2, 2)
+
+		actualResults, actualError := finder.Find(context.Background(), 1)
+
+		assert.Nil(t, actualError)
+		assert.Empty(t, actualResults)
+	})
+
+	t.Run("should return nil and error for done context", func(t *testing.T) {
+		finder, _ := periodic.New(rule.Default(), 4, 2, 5, 6)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		actualResults, actualError := finder.Find(ctx, 1)
+
+		assert.Nil(t, actualResults)
+		assert.EqualError(t, actualError, context.Canceled.Error())
+	})
+
+	t.Run("should return results found before context is done", func(t *testing.T) {
+		finder, _ := periodic.New(rule.Default(), 2, 0, 5, 5)
+		ctx := newCheckedContext(1)
+
+		actualResults, actualError := finder.Find(ctx, 1000)
+
+		assert.Equal(t, context.Canceled, actualError)
+		assert.NotEmpty(t, actualResults)
+		for _, result := range actualResults {
+			actualPeriod, _, _, _ := apgcode.Classify(result, 2)
+			assert.Equal(t, 2, actualPeriod)
+		}
+	})
+}
+
+func TestParseVelocity(t *testing.T) {
+	t.Run("should return error for invalid velocity", func(t *testing.T) {
+		testCases := []string{"", "c", "2c/2", "c/0", "c/", "3c/2", "0c/2", "xc/2", "c/2/3", "1/2"}
+
+		for _, testCase := range testCases {
+			_, _, actualError := periodic.ParseVelocity(testCase)
+
+			assert.EqualError(t, actualError, periodic.InvalidVelocityError, testCase)
+		}
+	})
+
+	t.Run("should return shift and period of velocity", func(t *testing.T) {
+		testCases := []struct {
+			velocity       string
+			expectedShift  int
+			expectedPeriod int
+		}{
+			{"0", 0, 1},
+			{"c/2", 1, 2},
+			{"2c/5", 2, 5},
+			{"c/10", 1, 10},
+		}
+
+		for _, testCase := range testCases {
+			actualShift, actualPeriod, actualError := periodic.ParseVelocity(testCase.velocity)
+
+			assert.Nil(t, actualError)
+			assert.Equal(t, testCase.expectedShift, actualShift)
+			assert.Equal(t, testCase.expectedPeriod, actualPeriod)
+		}
+	})
+}
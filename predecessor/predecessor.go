@@ -0,0 +1,174 @@
+package predecessor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/irainia/gameoflife-go/sat"
+)
+
+const (
+	NilCellStateError       = "cell state passed is nil"
+	ExtinctCellStateError   = "cell state passed is extinct"
+	NegativeMarginError     = "margin is negative"
+	InvalidMaxResultsError  = "max results is less than one (should be at least 1)"
+	MultiStateRuleError     = "rule with more than two states is not supported"
+	UnsupportedRadiusError  = "rule with a neighborhood beyond the adjacent cells is not supported"
+	UnverifiedSolutionError = "predecessor found does not evolve into the cell state"
+)
+
+var (
+	ErrNilCellState       = errors.New(NilCellStateError)
+	ErrExtinctCellState   = errors.New(ExtinctCellStateError)
+	ErrNegativeMargin     = errors.New(NegativeMarginError)
+	ErrInvalidMaxResults  = errors.New(InvalidMaxResultsError)
+	ErrMultiStateRule     = errors.New(MultiStateRuleError)
+	ErrUnsupportedRadius  = errors.New(UnsupportedRadiusError)
+	ErrUnverifiedSolution = errors.New(UnverifiedSolutionError)
+)
+
+const (
+	numOfNeighborhood = cell.NeighborhoodSide * cell.NeighborhoodSide
+)
+
+// Find searches the cells within margin of the bounding box of cellState
+// for up to maxResults generations that evolve into it in one step, each a
+// different assignment of the box. No result proves that cellState has no
+// predecessor within the box, e.g. that it is a Garden of Eden when the
+// box is large enough. The search stops with the error of ctx when it is
+// done first, returning the predecessors found until then.
+//
+// The box is encoded as satisfiability: a variable per cell and, for every
+// cell the box can affect, a clause ruling out each assignment of its
+// three by three neighborhood that gives it the wrong next state.
+func Find(ctx context.Context, cellState *cell.CellState, margin, maxResults int) ([]*cell.CellState, error) {
+	if cellState == nil {
+		return nil, ErrNilCellState
+	}
+	if margin < 0 {
+		return nil, ErrNegativeMargin
+	}
+	if maxResults < 1 {
+		return nil, ErrInvalidMaxResults
+	}
+	cellRule := cellState.GetRule()
+	if cellRule.GetNumOfStates() > rule.MinNumOfStates {
+		return nil, ErrMultiStateRule
+	}
+	if cellRule.GetRadius() > 1 {
+		return nil, ErrUnsupportedRadius
+	}
+	target := cellState.GetGeneration()
+	if len(target) == 0 {
+		return nil, ErrExtinctCellState
+	}
+
+	nextStates, err := cell.GetNextStates(cellRule)
+	if err != nil {
+		return nil, err
+	}
+
+	height, width := len(target)+2*margin, len(target[0])+2*margin
+	solver, err := sat.New(height * width)
+	if err != nil {
+		return nil, err
+	}
+	for i := -1; i <= height; i++ {
+		for j := -1; j <= width; j++ {
+			p, q := i-margin, j-margin
+			isAlive := p >= 0 && p < len(target) && q >= 0 && q < len(target[0]) && target[p][q]
+			if err = addCellClauses(solver, nextStates, height, width, i, j, isAlive); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rowOffset, colOffset := cellState.GetOffset()
+	predecessors := make([]*cell.CellState, 0)
+	for len(predecessors) < maxResults {
+		isSatisfied, err := solver.Solve(ctx)
+		if err != nil {
+			return predecessors, err
+		}
+		if !isSatisfied {
+			break
+		}
+
+		generation := make([][]bool, height)
+		blocking := make([]int, 0, height*width)
+		for i := 0; i < height; i++ {
+			generation[i] = make([]bool, width)
+			for j := 0; j < width; j++ {
+				variable := i*width + j + 1
+				generation[i][j] = solver.GetValue(variable)
+				if generation[i][j] {
+					blocking = append(blocking, -variable)
+				} else {
+					blocking = append(blocking, variable)
+				}
+			}
+		}
+
+		predecessor, err := cell.NewWithOffset(generation, cellRule, rowOffset-margin, colOffset-margin)
+		if err != nil {
+			return nil, err
+		}
+		if !predecessor.GetNextState().IsEqual(cellState) {
+			return nil, ErrUnverifiedSolution
+		}
+		predecessors = append(predecessors, predecessor)
+		if err = solver.AddClause(blocking...); err != nil {
+			return nil, err
+		}
+	}
+
+	return predecessors, nil
+}
+
+// IsGardenOfEden tells whether cellState has no predecessor within margin
+// of its bounding box.
+func IsGardenOfEden(ctx context.Context, cellState *cell.CellState, margin int) (bool, error) {
+	predecessors, err := Find(ctx, cellState, margin, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(predecessors) == 0, nil
+}
+
+// addCellClauses rules out every assignment around cell (row, col) of the
+// box whose next state is not isAlive, the cells outside the box being dead.
+func addCellClauses(solver *sat.Solver, nextStates []bool, height, width, row, col int, isAlive bool) error {
+	for assignment := 0; assignment < cell.NumOfAssignments; assignment++ {
+		if nextStates[assignment] == isAlive {
+			continue
+		}
+
+		clause := make([]int, 0, numOfNeighborhood)
+		isPossible := true
+		for k := 0; k < numOfNeighborhood && isPossible; k++ {
+			i, j := row+k/cell.NeighborhoodSide-1, col+k%cell.NeighborhoodSide-1
+			isSet := assignment&(1<<uint(k)) != 0
+			if i < 0 || i >= height || j < 0 || j >= width {
+				isPossible = !isSet
+				continue
+			}
+
+			variable := i*width + j + 1
+			if isSet {
+				clause = append(clause, -variable)
+			} else {
+				clause = append(clause, variable)
+			}
+		}
+		if !isPossible {
+			continue
+		}
+		if err := solver.AddClause(clause...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
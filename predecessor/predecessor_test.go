@@ -0,0 +1,167 @@
+package predecessor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/predecessor"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	gliderGeneration = [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+	squareGeneration = [][]bool{
+		{true, true, true},
+		{true, true, true},
+		{true, true, true},
+	}
+)
+
+// checkedContext is done once it has been checked numOfChecks times, so a
+// search stops after a known number of steps.
+type checkedContext struct {
+	context.Context
+	numOfChecks int
+	done        chan struct{}
+}
+
+func newCheckedContext(numOfChecks int) *checkedContext {
+	return &checkedContext{
+		Context:     context.Background(),
+		numOfChecks: numOfChecks,
+		done:        make(chan struct{}),
+	}
+}
+
+func (ctx *checkedContext) Done() <-chan struct{} {
+	if ctx.numOfChecks == 0 && ctx.Err() == nil {
+		close(ctx.done)
+	}
+	ctx.numOfChecks--
+	return ctx.done
+}
+
+func (ctx *checkedContext) Err() error {
+	select {
+	case <-ctx.done:
+		return context.Canceled
+	default:
+		return nil
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Run("should return nil and error for invalid arguments", func(t *testing.T) {
+		glider, _ := cell.New(gliderGeneration)
+		starWars, _ := rule.New("B2/S345/C4")
+		generations, _ := cell.NewWithRule(gliderGeneration, starWars)
+		bosco, _ := rule.New("R5,C0,M1,S34..58,B34..45,NM")
+		largerThanLife, _ := cell.NewWithRule(gliderGeneration, bosco)
+		single, _ := cell.New([][]bool{{true}})
+		extinct := single.GetNextState()
+		testCases := []struct {
+			cellState     *cell.CellState
+			margin        int
+			maxResults    int
+			expectedError string
+		}{
+			{nil, 1, 1, predecessor.NilCellStateError},
+			{glider, -1, 1, predecessor.NegativeMarginError},
+			{glider, 1, 0, predecessor.InvalidMaxResultsError},
+			{generations, 1, 1, predecessor.MultiStateRuleError},
+			{largerThanLife, 1, 1, predecessor.UnsupportedRadiusError},
+			{extinct, 1, 1, predecessor.ExtinctCellStateError},
+		}
+
+		for _, testCase := range testCases {
+			actualPredecessors, actualError := predecessor.Find(context.Background(), testCase.cellState, testCase.margin, testCase.maxResults)
+
+			assert.Nil(t, actualPredecessors)
+			assert.EqualError(t, actualError, testCase.expectedError)
+		}
+	})
+
+	t.Run("should return different predecessors evolving into cell state", func(t *testing.T) {
+		glider, _ := cell.NewWithOffset(gliderGeneration, rule.Default(), 10, -4)
+
+		actualPredecessors, actualError := predecessor.Find(context.Background(), glider, 1, 4)
+
+		assert.Nil(t, actualError)
+		assert.Len(t, actualPredecessors, 4)
+		for i, actualPredecessor := range actualPredecessors {
+			assert.True(t, glider.IsEqual(actualPredecessor.GetNextState()))
+			for _, other := range actualPredecessors[:i] {
+				assert.False(t, other.IsEqual(actualPredecessor))
+			}
+		}
+	})
+
+	t.Run("should find predecessor on other rules", func(t *testing.T) {
+		notations := []string{"B36/S23", "B2a/S12", "B2/S34H", "WireWorld"}
+
+		for _, notation := range notations {
+			cellRule, _ := rule.New(notation)
+			if cellRule.GetNumOfStates() > rule.MinNumOfStates {
+				continue
+			}
+			cellState, _ := cell.NewWithRule(gliderGeneration, cellRule)
+			nextState := cellState.GetNextState()
+
+			actualPredecessors, actualError := predecessor.Find(context.Background(), nextState, 1, 1)
+
+			assert.Nil(t, actualError, notation)
+			assert.Len(t, actualPredecessors, 1, notation)
+			assert.True(t, nextState.IsEqual(actualPredecessors[0].GetNextState()), notation)
+		}
+	})
+
+	t.Run("should return error of done context", func(t *testing.T) {
+		glider, _ := cell.New(gliderGeneration)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		actualPredecessors, actualError := predecessor.Find(ctx, glider, 4, 1000)
+
+		assert.Empty(t, actualPredecessors)
+		assert.Equal(t, context.Canceled, actualError)
+	})
+
+	t.Run("should return predecessors found before context is done", func(t *testing.T) {
+		glider, _ := cell.New(gliderGeneration)
+		ctx := newCheckedContext(2)
+
+		actualPredecessors, actualError := predecessor.Find(ctx, glider, 1, 4)
+
+		assert.Equal(t, context.Canceled, actualError)
+		assert.Len(t, actualPredecessors, 2)
+		for _, actualPredecessor := range actualPredecessors {
+			assert.True(t, glider.IsEqual(actualPredecessor.GetNextState()))
+		}
+	})
+}
+
+func TestIsGardenOfEden(t *testing.T) {
+	t.Run("should be garden of eden without room around it", func(t *testing.T) {
+		square, _ := cell.New(squareGeneration)
+
+		actualIsGardenOfEden, actualError := predecessor.IsGardenOfEden(context.Background(), square, 0)
+
+		assert.Nil(t, actualError)
+		assert.True(t, actualIsGardenOfEden)
+	})
+
+	t.Run("should not be garden of eden with room around it", func(t *testing.T) {
+		square, _ := cell.New(squareGeneration)
+
+		actualIsGardenOfEden, actualError := predecessor.IsGardenOfEden(context.Background(), square, 1)
+
+		assert.Nil(t, actualError)
+		assert.False(t, actualIsGardenOfEden)
+	})
+}
@@ -0,0 +1,219 @@
+package rule
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	excludedLetters = '-'
+	halfNeighbors   = maxNeighbors / 2
+)
+
+// henselLetters and henselBlocks list, for up to four living neighbors, the
+// Hensel letters and one neighborhood of each, as the bits of the 3x3 block
+// read row by row with the cell itself as bit 4. Five to eight living
+// neighbors use the letters of three down to zero for the complements.
+var (
+	henselLetters = [halfNeighbors + 1]string{
+		"",
+		"ce",
+		"ceaikn",
+		"ceaiknjqry",
+		"ceaiknjqrtwyz",
+	}
+	henselBlocks = [halfNeighbors + 1][]int{
+		{0},
+		{1, 2},
+		{5, 10, 3, 40, 33, 68},
+		{69, 42, 11, 7, 98, 13, 14, 70, 41, 97},
+		{325, 170, 15, 45, 99, 71, 106, 102, 43, 101, 105, 78, 108},
+	}
+
+	// letterNeighborhoods holds every rotation and reflection of each
+	// Hensel letter by number of living neighbors.
+	letterNeighborhoods = makeLetterNeighborhoods()
+)
+
+func makeLetterNeighborhoods() [maxNeighbors + 1]map[byte][]uint8 {
+	var letterNeighborhoods [maxNeighbors + 1]map[byte][]uint8
+	for numOfNeighbors := 0; numOfNeighbors <= halfNeighbors; numOfNeighbors++ {
+		letters := henselLetters[numOfNeighbors]
+		letterNeighborhoods[numOfNeighbors] = make(map[byte][]uint8)
+		letterNeighborhoods[maxNeighbors-numOfNeighbors] = make(map[byte][]uint8)
+		isComplemented := numOfNeighbors < halfNeighbors
+		for i, block := range henselBlocks[numOfNeighbors] {
+			letter := byte(0)
+			if letters != "" {
+				letter = letters[i]
+			}
+
+			neighborhoods := getSymmetries(blockToNeighborhood(block))
+			letterNeighborhoods[numOfNeighbors][letter] = neighborhoods
+			if !isComplemented {
+				continue
+			}
+			complements := make([]uint8, len(neighborhoods))
+			for j, neighborhood := range neighborhoods {
+				complements[j] = ^neighborhood
+			}
+			letterNeighborhoods[maxNeighbors-numOfNeighbors][letter] = complements
+		}
+	}
+
+	return letterNeighborhoods
+}
+
+func blockToNeighborhood(block int) uint8 {
+	const center = 4
+	low := block & (1<<center - 1)
+	high := block >> (center + 1)
+	return uint8(low | high<<center)
+}
+
+// getSymmetries returns neighborhood under the eight rotations and
+// reflections of the square, without duplicates.
+func getSymmetries(neighborhood uint8) []uint8 {
+	symmetries := make([]uint8, 0)
+	isAdded := make(map[uint8]bool)
+	for symmetry := 0; symmetry < 8; symmetry++ {
+		var transformed uint8
+		for bit := 0; bit < maxNeighbors; bit++ {
+			if neighborhood&(1<<uint(bit)) == 0 {
+				continue
+			}
+
+			position := bit
+			if position >= 4 {
+				position++
+			}
+			row, col := position/3-1, position%3-1
+			if symmetry&4 != 0 {
+				row, col = col, row
+			}
+			if symmetry&2 != 0 {
+				row = -row
+			}
+			if symmetry&1 != 0 {
+				col = -col
+			}
+			transformed |= blockToNeighborhood(1 << uint((row+1)*3+col+1))
+		}
+		if !isAdded[transformed] {
+			isAdded[transformed] = true
+			symmetries = append(symmetries, transformed)
+		}
+	}
+
+	return symmetries
+}
+
+// parseNeighborhoods reads a B or S part such as 2-a3ij4 into table, a
+// number alone meaning every neighborhood with that many living neighbors.
+func parseNeighborhoods(part string, table *[NumOfNeighborhoods]bool) bool {
+	for i := 0; i < len(part); {
+		if part[i] < '0' || part[i] > '0'+maxNeighbors {
+			return false
+		}
+		numOfNeighbors := int(part[i] - '0')
+		i++
+
+		isExcluded := i < len(part) && part[i] == excludedLetters
+		if isExcluded {
+			i++
+		}
+		start := i
+		for i < len(part) && (part[i] < '0' || part[i] > '9') {
+			i++
+		}
+		letters := part[start:i]
+		if isExcluded && letters == "" {
+			return false
+		}
+
+		isChosen := make(map[byte]bool)
+		for j := 0; j < len(letters); j++ {
+			if _, isFound := letterNeighborhoods[numOfNeighbors][letters[j]]; !isFound {
+				return false
+			}
+			isChosen[letters[j]] = true
+		}
+		for letter, neighborhoods := range letterNeighborhoods[numOfNeighbors] {
+			if letters == "" || isChosen[letter] != isExcluded {
+				for _, neighborhood := range neighborhoods {
+					table[neighborhood] = true
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// getLetterOrder returns the Hensel letters of numOfNeighbors living
+// neighbors in alphabetical order, the order they are written in.
+func getLetterOrder(numOfNeighbors int) string {
+	if numOfNeighbors > halfNeighbors {
+		numOfNeighbors = maxNeighbors - numOfNeighbors
+	}
+
+	letters := []byte(henselLetters[numOfNeighbors])
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i] < letters[j]
+	})
+	return string(letters)
+}
+
+// getLetters returns the Hensel letters set in table for numOfNeighbors
+// living neighbors in notation order, how many of the neighborhoods the
+// letters stand for are set and how many there are.
+func getLetters(table *[NumOfNeighborhoods]bool, numOfNeighbors int) (string, int, int) {
+	letterOrder := getLetterOrder(numOfNeighbors)
+	if letterOrder == "" {
+		if table[letterNeighborhoods[numOfNeighbors][0][0]] {
+			return "", 1, 1
+		}
+		return "", 0, 1
+	}
+
+	var buffer bytes.Buffer
+	for i := 0; i < len(letterOrder); i++ {
+		if table[letterNeighborhoods[numOfNeighbors][letterOrder[i]][0]] {
+			buffer.WriteByte(letterOrder[i])
+		}
+	}
+
+	return buffer.String(), buffer.Len(), len(letterOrder)
+}
+
+// formatNeighborhoods writes table the shortest way: a number alone when
+// every letter is set, the letters set, or the letters not set after '-'.
+func formatNeighborhoods(table *[NumOfNeighborhoods]bool) string {
+	var buffer bytes.Buffer
+	for numOfNeighbors := 0; numOfNeighbors <= maxNeighbors; numOfNeighbors++ {
+		letters, numOfSet, numOfLetters := getLetters(table, numOfNeighbors)
+		if numOfSet == 0 {
+			continue
+		}
+
+		buffer.WriteString(strconv.Itoa(numOfNeighbors))
+		if numOfSet == numOfLetters {
+			continue
+		}
+		if 2*numOfSet <= numOfLetters {
+			buffer.WriteString(letters)
+			continue
+		}
+		buffer.WriteByte(excludedLetters)
+		letterOrder := getLetterOrder(numOfNeighbors)
+		for i := 0; i < len(letterOrder); i++ {
+			if !strings.ContainsRune(letters, rune(letterOrder[i])) {
+				buffer.WriteByte(letterOrder[i])
+			}
+		}
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,165 @@
+package rule
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	InvalidLargerThanLifeError = "larger than life rule is invalid (use: R[1-500],C[0,2-36],M[0-1],S[min]..[max],B[min]..[max],N[M/N], e.g. R5,C0,M1,S34..58,B34..45,NM)"
+)
+
+var (
+	ErrInvalidLargerThanLife = errors.New(InvalidLargerThanLifeError)
+)
+
+const (
+	MaxRadius = 500
+
+	radiusPrefix       = "R"
+	rangeStatesPrefix  = "C"
+	centerPrefix       = "M"
+	neighborhoodPrefix = "N"
+	rangeSeparator     = ".."
+	fieldSeparator     = ","
+
+	mooreSuffix      = "M"
+	vonNeumannSuffix = "N"
+
+	numOfFields = 6
+)
+
+// isLargerThanLife tells whether notation is in the Larger than Life form,
+// e.g. R5,C0,M1,S34..58,B34..45,NM.
+func isLargerThanLife(notation string) bool {
+	return strings.HasPrefix(notation, radiusPrefix) && strings.Contains(notation, fieldSeparator)
+}
+
+// parseLargerThanLife reads a rule counting the living cells within radius
+// R on the Moore (NM) or von Neumann (NN) neighborhood, the cell itself
+// among them when M is 1. The cell is born or survives when the count is
+// within the B or S range, and C above 2 decays it as in Generations.
+func parseLargerThanLife(notation string) (*Rule, error) {
+	fields := strings.Split(notation, fieldSeparator)
+	if len(fields) != numOfFields {
+		return nil, ErrInvalidLargerThanLife
+	}
+
+	var rule Rule
+	radius, isRadiusValid := parseField(fields[0], radiusPrefix)
+	numOfStates, isNumOfStatesValid := parseField(fields[1], rangeStatesPrefix)
+	center, isCenterValid := parseField(fields[2], centerPrefix)
+	if !isRadiusValid || !isNumOfStatesValid || !isCenterValid ||
+		radius < 1 || radius > MaxRadius || center > 1 {
+		return nil, ErrInvalidLargerThanLife
+	}
+	if numOfStates == 0 {
+		numOfStates = MinNumOfStates
+	}
+	if numOfStates < MinNumOfStates || numOfStates > MaxNumOfStates {
+		return nil, ErrInvalidNumOfStates
+	}
+
+	var maxCount int
+	switch fields[5] {
+	case neighborhoodPrefix + mooreSuffix:
+		rule.neighborhood = Moore
+		maxCount = (2*radius + 1) * (2*radius + 1)
+	case neighborhoodPrefix + vonNeumannSuffix:
+		rule.neighborhood = VonNeumann
+		maxCount = 2*radius*(radius+1) + 1
+	default:
+		return nil, ErrInvalidLargerThanLife
+	}
+	if center == 0 {
+		maxCount--
+	}
+
+	var isSurvivalValid, isBirthValid bool
+	rule.survivalCount, isSurvivalValid = parseRange(fields[3], survivalPrefix, maxCount)
+	rule.birthCount, isBirthValid = parseRange(fields[4], birthPrefix, maxCount)
+	if !isSurvivalValid || !isBirthValid {
+		return nil, ErrInvalidLargerThanLife
+	}
+	if rule.birthCount[0] {
+		return nil, ErrBirthOnZero
+	}
+
+	rule.isLargerThanLife = true
+	rule.isCenterIncluded = center == 1
+	rule.radius = radius
+	rule.numOfStates = numOfStates
+	return &rule, nil
+}
+
+// parseField reads a field made of prefix and a whole number.
+func parseField(field, prefix string) (int, bool) {
+	if !strings.HasPrefix(field, prefix) || !isDigits(field[len(prefix):]) {
+		return 0, false
+	}
+
+	value, err := strconv.Atoi(field[len(prefix):])
+	return value, err == nil
+}
+
+// parseRange reads a field made of prefix and min..max into counts.
+func parseRange(field, prefix string, maxCount int) ([]bool, bool) {
+	if !strings.HasPrefix(field, prefix) {
+		return nil, false
+	}
+	bounds := strings.Split(field[len(prefix):], rangeSeparator)
+	if len(bounds) != 2 || !isDigits(bounds[0]) || !isDigits(bounds[1]) {
+		return nil, false
+	}
+
+	min, minErr := strconv.Atoi(bounds[0])
+	max, maxErr := strconv.Atoi(bounds[1])
+	if minErr != nil || maxErr != nil || min > max || max > maxCount {
+		return nil, false
+	}
+
+	counts := make([]bool, maxCount+1)
+	for count := min; count <= max; count++ {
+		counts[count] = true
+	}
+	return counts, true
+}
+
+func formatLargerThanLife(rule *Rule) string {
+	numOfStates, center, suffix := rule.numOfStates, 0, mooreSuffix
+	if numOfStates == MinNumOfStates {
+		numOfStates = 0
+	}
+	if rule.isCenterIncluded {
+		center = 1
+	}
+	if rule.neighborhood == VonNeumann {
+		suffix = vonNeumannSuffix
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(radiusPrefix + strconv.Itoa(rule.radius) + fieldSeparator)
+	buffer.WriteString(rangeStatesPrefix + strconv.Itoa(numOfStates) + fieldSeparator)
+	buffer.WriteString(centerPrefix + strconv.Itoa(center) + fieldSeparator)
+	buffer.WriteString(survivalPrefix + formatRange(rule.survivalCount) + fieldSeparator)
+	buffer.WriteString(birthPrefix + formatRange(rule.birthCount) + fieldSeparator)
+	buffer.WriteString(neighborhoodPrefix + suffix)
+
+	return buffer.String()
+}
+
+func formatRange(counts []bool) string {
+	min, max := -1, -1
+	for count := 0; count < len(counts); count++ {
+		if counts[count] {
+			if min < 0 {
+				min = count
+			}
+			max = count
+		}
+	}
+
+	return strconv.Itoa(min) + rangeSeparator + strconv.Itoa(max)
+}
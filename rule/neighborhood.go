@@ -0,0 +1,125 @@
+package rule
+
+import (
+	"bytes"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const (
+	Moore      = ""
+	VonNeumann = "V"
+	Hexagonal  = "H"
+	Weighted   = "W"
+
+	countSeparator = ","
+	minMaskSide    = 3
+
+	// vonNeumannMask keeps N, W, E and S and hexagonalMask drops NE and SW,
+	// so a hexagonal grid is drawn on the square one sheared to the right.
+	vonNeumannMask uint8 = 1<<1 | 1<<3 | 1<<4 | 1<<6
+	hexagonalMask  uint8 = ^uint8(1<<2 | 1<<5)
+)
+
+// splitNeighborhood takes the neighborhood suffix off notation, returning
+// the weights of a Weighted mask row by row.
+func splitNeighborhood(notation string) (string, string, [][]int) {
+	if index := strings.LastIndex(notation, Weighted); index >= 0 {
+		digits := notation[index+1:]
+		side := int(math.Sqrt(float64(len(digits))))
+		if len(digits) >= minMaskSide*minMaskSide && side*side == len(digits) && side%2 == 1 && isDigits(digits) {
+			weights := make([][]int, side)
+			for i := 0; i < side; i++ {
+				weights[i] = make([]int, side)
+				for j := 0; j < side; j++ {
+					weights[i][j] = int(digits[i*side+j] - '0')
+				}
+			}
+			return notation[:index], Weighted, weights
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(notation, VonNeumann):
+		return strings.TrimSuffix(notation, VonNeumann), VonNeumann, nil
+	case strings.HasSuffix(notation, Hexagonal):
+		return strings.TrimSuffix(notation, Hexagonal), Hexagonal, nil
+	default:
+		return notation, Moore, nil
+	}
+}
+
+func isDigits(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCounts reads a B or S part of a rule that is not on the Moore
+// neighborhood: one digit per count, or counts separated by ',' when some
+// are more than 9.
+func parseCounts(part string, maxCount int) ([]bool, bool) {
+	counts := make([]bool, maxCount+1)
+	if part == "" {
+		return counts, true
+	}
+
+	var values []string
+	if strings.Contains(part, countSeparator) {
+		values = strings.Split(part, countSeparator)
+	} else {
+		values = strings.Split(part, "")
+	}
+	for _, value := range values {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 || count > maxCount || !isDigits(value) {
+			return nil, false
+		}
+		counts[count] = true
+	}
+
+	return counts, true
+}
+
+func formatCounts(counts []bool) string {
+	var buffer bytes.Buffer
+	separator := ""
+	if len(counts) > 10 {
+		separator = countSeparator
+	}
+	for count := 0; count < len(counts); count++ {
+		if !counts[count] {
+			continue
+		}
+		if buffer.Len() > 0 {
+			buffer.WriteString(separator)
+		}
+		buffer.WriteString(strconv.Itoa(count))
+	}
+
+	return buffer.String()
+}
+
+func formatWeights(weights [][]int) string {
+	var buffer bytes.Buffer
+	for i := 0; i < len(weights); i++ {
+		for j := 0; j < len(weights[i]); j++ {
+			buffer.WriteString(strconv.Itoa(weights[i][j]))
+		}
+	}
+
+	return buffer.String()
+}
+
+// fillByMask sets table for every neighborhood whose living neighbors
+// within mask are one of counts.
+func fillByMask(table *[NumOfNeighborhoods]bool, counts []bool, mask uint8) {
+	for neighborhood := 0; neighborhood < NumOfNeighborhoods; neighborhood++ {
+		table[neighborhood] = counts[bits.OnesCount8(uint8(neighborhood)&mask)]
+	}
+}
@@ -0,0 +1,380 @@
+package rule
+
+import (
+	"bytes"
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	EmptyNotationError       = "rule notation passed is empty"
+	InvalidNotationError     = "rule notation is invalid (use: B[0-8]/S[0-8] or B[0-8]/S[0-8]/C[2-36], e.g. B3/S23 or B2-a/S12)"
+	BirthOnZeroError         = "rule with birth on zero neighbor is not supported"
+	InvalidNumOfStatesError  = "number of states is invalid (should be from 2 to 36)"
+	NeighborhoodLettersError = "hensel letters are only supported on the moore neighborhood"
+)
+
+var (
+	ErrEmptyNotation       = errors.New(EmptyNotationError)
+	ErrInvalidNotation     = errors.New(InvalidNotationError)
+	ErrBirthOnZero         = errors.New(BirthOnZeroError)
+	ErrInvalidNumOfStates  = errors.New(InvalidNumOfStatesError)
+	ErrNeighborhoodLetters = errors.New(NeighborhoodLettersError)
+)
+
+const (
+	Conway = "B3/S23"
+
+	birthPrefix    = "B"
+	survivalPrefix = "S"
+	statesPrefix   = "C"
+	gollyPrefix    = "G"
+	partSeparator  = "/"
+
+	maxNeighbors = 8
+)
+
+const (
+	Dead  uint8 = 0
+	Alive uint8 = 1
+
+	MinNumOfStates = 2
+	MaxNumOfStates = 36
+
+	// NumOfNeighborhoods is the number of ways the eight neighbors of a
+	// cell can be alive. A neighborhood has bit 0 to 7 set for a living
+	// neighbor at NW, N, NE, W, E, SW, S and SE respectively.
+	NumOfNeighborhoods = 1 << maxNeighbors
+)
+
+// Rule is an isotropic rule on the eight neighbors of a cell, given in B/S
+// notation with optional Hensel letters, e.g. B2-a/S12. A suffix picks
+// another neighborhood: V for von Neumann, H for hexagonal or W and the
+// weights of a square mask row by row, e.g. B2/S013V or B3/S23W111101111.
+// With more than two states it is a Generations rule: a living cell that
+// does not survive decays through states 2 to numOfStates-1 before dying,
+// and only state 1 counts as a living neighbor. A Larger than Life rule
+// counts the neighbors within a radius instead, see parseLargerThanLife,
+// and a rule table gives the next state of each neighborhood, see Load.
+type Rule struct {
+	birth         [NumOfNeighborhoods]bool
+	survival      [NumOfNeighborhoods]bool
+	birthCount    []bool
+	survivalCount []bool
+	isTotalistic  bool
+	numOfStates   int
+
+	neighborhood string
+	weights      [][]int
+	radius       int
+
+	isLargerThanLife bool
+	isCenterIncluded bool
+
+	table    *table
+	notation string
+}
+
+// IsBorn tells whether a dead cell with numOfNeighbors living neighbors is
+// born whichever neighbors they are.
+func (rule *Rule) IsBorn(numOfNeighbors int) bool {
+	if numOfNeighbors < 0 || numOfNeighbors >= len(rule.birthCount) {
+		return false
+	}
+	return rule.birthCount[numOfNeighbors]
+}
+
+// IsSurvived tells whether a living cell with numOfNeighbors living
+// neighbors survives whichever neighbors they are.
+func (rule *Rule) IsSurvived(numOfNeighbors int) bool {
+	if numOfNeighbors < 0 || numOfNeighbors >= len(rule.survivalCount) {
+		return false
+	}
+	return rule.survivalCount[numOfNeighbors]
+}
+
+func (rule *Rule) GetNeighborhood() string {
+	return rule.neighborhood
+}
+
+// GetWeights returns the mask of a Weighted rule, centred on the cell, and
+// nil for the other neighborhoods.
+func (rule *Rule) GetWeights() [][]int {
+	if rule.weights == nil {
+		return nil
+	}
+
+	weights := make([][]int, len(rule.weights))
+	for i := 0; i < len(rule.weights); i++ {
+		weights[i] = make([]int, len(rule.weights[i]))
+		copy(weights[i], rule.weights[i])
+	}
+	return weights
+}
+
+// GetRadius returns how far the neighborhood reaches from the cell.
+func (rule *Rule) GetRadius() int {
+	return rule.radius
+}
+
+// IsLargerThanLife tells whether the rule counts the living cells within
+// GetRadius on its neighborhood, stepped by GetNextState on that count.
+func (rule *Rule) IsLargerThanLife() bool {
+	return rule.isLargerThanLife
+}
+
+// IsCenterIncluded tells whether a Larger than Life rule counts the cell
+// itself among its neighbors.
+func (rule *Rule) IsCenterIncluded() bool {
+	return rule.isCenterIncluded
+}
+
+// IsTotalistic tells whether the next state depends only on the number of
+// living neighbors among all eight, so GetNextState can be used instead of
+// GetNextStateByNeighborhood. Weighted rules are stepped by GetNextState on
+// the weighted count instead.
+func (rule *Rule) IsTotalistic() bool {
+	return rule.isTotalistic
+}
+
+// IsTable tells whether the rule is a rule table, stepped by
+// GetNextStateByTable.
+func (rule *Rule) IsTable() bool {
+	return rule.table != nil
+}
+
+// GetNeighbors returns the row and column offsets of the neighbors of a
+// rule table in the order GetNextStateByTable takes them.
+func (rule *Rule) GetNeighbors() [][2]int {
+	if rule.table == nil {
+		return nil
+	}
+
+	neighbors := make([][2]int, len(rule.table.neighbors))
+	copy(neighbors, rule.table.neighbors)
+	return neighbors
+}
+
+// GetNextStateByTable returns the state following states[0] for a cell of a
+// rule table with neighbors states[1:], see GetNeighbors.
+func (rule *Rule) GetNextStateByTable(states []uint8) uint8 {
+	return rule.table.nextState(states)
+}
+
+// GetColors returns the color of each state given by a rule table, as red,
+// green and blue.
+func (rule *Rule) GetColors() map[uint8][3]uint8 {
+	colors := make(map[uint8][3]uint8)
+	if rule.table != nil {
+		for state, color := range rule.table.colors {
+			colors[state] = color
+		}
+	}
+	return colors
+}
+
+func (rule *Rule) GetNumOfStates() int {
+	return rule.numOfStates
+}
+
+// GetNextState returns the state following state for a cell with
+// numOfNeighbors living neighbors. It is only exact for totalistic rules.
+func (rule *Rule) GetNextState(state uint8, numOfNeighbors int) uint8 {
+	return rule.nextState(state, rule.IsBorn(numOfNeighbors), rule.IsSurvived(numOfNeighbors))
+}
+
+// GetNextStateByNeighborhood returns the state following state for a cell
+// whose living neighbors are neighborhood, see NumOfNeighborhoods.
+func (rule *Rule) GetNextStateByNeighborhood(state uint8, neighborhood uint8) uint8 {
+	return rule.nextState(state, rule.birth[neighborhood], rule.survival[neighborhood])
+}
+
+func (rule *Rule) nextState(state uint8, isBorn, isSurvived bool) uint8 {
+	switch {
+	case state == Dead:
+		if isBorn {
+			return Alive
+		}
+		return Dead
+	case state == Alive && isSurvived:
+		return Alive
+	case int(state)+1 < rule.numOfStates:
+		return state + 1
+	default:
+		return Dead
+	}
+}
+
+func (rule *Rule) String() string {
+	if rule.isLargerThanLife {
+		return formatLargerThanLife(rule)
+	}
+	if rule.table != nil {
+		return rule.notation
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(birthPrefix)
+	if rule.neighborhood == Moore {
+		buffer.WriteString(formatNeighborhoods(&rule.birth))
+	} else {
+		buffer.WriteString(formatCounts(rule.birthCount))
+	}
+	buffer.WriteString(partSeparator)
+	buffer.WriteString(survivalPrefix)
+	if rule.neighborhood == Moore {
+		buffer.WriteString(formatNeighborhoods(&rule.survival))
+	} else {
+		buffer.WriteString(formatCounts(rule.survivalCount))
+	}
+	if rule.numOfStates > MinNumOfStates {
+		buffer.WriteString(partSeparator)
+		buffer.WriteString(statesPrefix)
+		buffer.WriteString(strconv.Itoa(rule.numOfStates))
+	}
+	buffer.WriteString(rule.neighborhood)
+	if rule.weights != nil {
+		buffer.WriteString(formatWeights(rule.weights))
+	}
+
+	return buffer.String()
+}
+
+func New(notation string) (*Rule, error) {
+	notation = strings.TrimSpace(notation)
+	if notation == "" {
+		return nil, ErrEmptyNotation
+	}
+	if content, isFound := builtInTables[strings.ToUpper(notation)]; isFound {
+		return ParseTable(content)
+	}
+	if isLargerThanLife(strings.ToUpper(notation)) {
+		return parseLargerThanLife(strings.ToUpper(notation))
+	}
+
+	notation, neighborhood, weights := splitNeighborhood(strings.ToUpper(notation))
+	parts := strings.Split(notation, partSeparator)
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, ErrInvalidNotation
+	}
+
+	var rule Rule
+	rule.numOfStates = MinNumOfStates
+	rule.neighborhood = neighborhood
+	rule.weights = weights
+	rule.radius = 1
+	if weights != nil {
+		rule.radius = len(weights) / 2
+	}
+	if len(parts) == 3 {
+		numOfStates, err := parseNumOfStates(parts[2])
+		if err != nil {
+			return nil, err
+		}
+		rule.numOfStates = numOfStates
+	}
+
+	var birthPart, survivalPart string
+	switch {
+	case strings.HasPrefix(parts[0], birthPrefix) && strings.HasPrefix(parts[1], survivalPrefix):
+		birthPart, survivalPart = parts[0][1:], parts[1][1:]
+	case strings.HasPrefix(parts[0], survivalPrefix) && strings.HasPrefix(parts[1], birthPrefix):
+		birthPart, survivalPart = parts[1][1:], parts[0][1:]
+	default:
+		// S/B notation without prefixes, e.g. 23/3 or 345/2/4
+		birthPart, survivalPart = parts[1], parts[0]
+	}
+
+	if neighborhood != Moore {
+		if err := rule.parseCounts(birthPart, survivalPart); err != nil {
+			return nil, err
+		}
+		return &rule, nil
+	}
+
+	if !parseNeighborhoods(strings.ToLower(birthPart), &rule.birth) ||
+		!parseNeighborhoods(strings.ToLower(survivalPart), &rule.survival) {
+		return nil, ErrInvalidNotation
+	}
+	if rule.birth[0] {
+		return nil, ErrBirthOnZero
+	}
+
+	rule.isTotalistic = true
+	rule.birthCount = make([]bool, maxNeighbors+1)
+	rule.survivalCount = make([]bool, maxNeighbors+1)
+	for numOfNeighbors := 0; numOfNeighbors <= maxNeighbors; numOfNeighbors++ {
+		_, numOfBirths, numOfLetters := getLetters(&rule.birth, numOfNeighbors)
+		_, numOfSurvivals, _ := getLetters(&rule.survival, numOfNeighbors)
+		rule.birthCount[numOfNeighbors] = numOfBirths == numOfLetters
+		rule.survivalCount[numOfNeighbors] = numOfSurvivals == numOfLetters
+		rule.isTotalistic = rule.isTotalistic &&
+			(numOfBirths == 0 || rule.birthCount[numOfNeighbors]) &&
+			(numOfSurvivals == 0 || rule.survivalCount[numOfNeighbors])
+	}
+
+	return &rule, nil
+}
+
+func Default() *Rule {
+	rule, _ := New(Conway)
+	return rule
+}
+
+// parseCounts reads the B and S parts of a rule on the von Neumann,
+// hexagonal or a weighted neighborhood, where only counts matter.
+func (rule *Rule) parseCounts(birthPart, survivalPart string) error {
+	maxCount := 0
+	switch rule.neighborhood {
+	case VonNeumann:
+		maxCount = bits.OnesCount8(vonNeumannMask)
+	case Hexagonal:
+		maxCount = bits.OnesCount8(hexagonalMask)
+	default:
+		for i := 0; i < len(rule.weights); i++ {
+			for j := 0; j < len(rule.weights[i]); j++ {
+				maxCount += rule.weights[i][j]
+			}
+		}
+	}
+
+	var isBirthValid, isSurvivalValid bool
+	rule.birthCount, isBirthValid = parseCounts(birthPart, maxCount)
+	rule.survivalCount, isSurvivalValid = parseCounts(survivalPart, maxCount)
+	if !isBirthValid || !isSurvivalValid {
+		if strings.IndexFunc(birthPart+survivalPart, unicode.IsLetter) >= 0 {
+			return ErrNeighborhoodLetters
+		}
+		return ErrInvalidNotation
+	}
+	if rule.birthCount[0] {
+		return ErrBirthOnZero
+	}
+
+	switch rule.neighborhood {
+	case VonNeumann:
+		fillByMask(&rule.birth, rule.birthCount, vonNeumannMask)
+		fillByMask(&rule.survival, rule.survivalCount, vonNeumannMask)
+	case Hexagonal:
+		fillByMask(&rule.birth, rule.birthCount, hexagonalMask)
+		fillByMask(&rule.survival, rule.survivalCount, hexagonalMask)
+	}
+
+	return nil
+}
+
+func parseNumOfStates(part string) (int, error) {
+	part = strings.TrimPrefix(strings.TrimPrefix(part, statesPrefix), gollyPrefix)
+	numOfStates, err := strconv.Atoi(part)
+	if err != nil {
+		return 0, ErrInvalidNotation
+	}
+	if numOfStates < MinNumOfStates || numOfStates > MaxNumOfStates {
+		return 0, ErrInvalidNumOfStates
+	}
+
+	return numOfStates, nil
+}
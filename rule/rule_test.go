@@ -0,0 +1,423 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for empty notation", func(t *testing.T) {
+		var expectedError = rule.EmptyNotationError
+
+		actualRule, actualError := rule.New("")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for notation without separator", func(t *testing.T) {
+		var expectedError = rule.InvalidNotationError
+
+		actualRule, actualError := rule.New("B3S23")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for neighbor count more than eight", func(t *testing.T) {
+		var expectedError = rule.InvalidNotationError
+
+		actualRule, actualError := rule.New("B9/S23")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for birth on zero neighbor", func(t *testing.T) {
+		var expectedError = rule.BirthOnZeroError
+
+		actualRule, actualError := rule.New("B03/S23")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should accept B/S notation", func(t *testing.T) {
+		var expectedNotation = "B36/S23"
+
+		actualRule, actualError := rule.New("B36/S23")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedNotation, actualRule.String())
+	})
+
+	t.Run("should accept S/B notation with prefix", func(t *testing.T) {
+		var expectedNotation = "B36/S23"
+
+		actualRule, actualError := rule.New("s23/b36")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedNotation, actualRule.String())
+	})
+
+	t.Run("should accept S/B notation without prefix", func(t *testing.T) {
+		var expectedNotation = "B36/S23"
+
+		actualRule, actualError := rule.New("23/36")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedNotation, actualRule.String())
+	})
+
+	t.Run("should accept empty survival", func(t *testing.T) {
+		var expectedNotation = "B2/S"
+
+		actualRule, actualError := rule.New("B2/S")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedNotation, actualRule.String())
+	})
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("should return conway rule", func(t *testing.T) {
+		var expectedNotation = rule.Conway
+
+		actualRule := rule.Default()
+
+		assert.Equal(t, expectedNotation, actualRule.String())
+	})
+}
+
+func TestIsBorn(t *testing.T) {
+	t.Run("should return true only for birth neighbor count", func(t *testing.T) {
+		conway := rule.Default()
+
+		assert.False(t, conway.IsBorn(2))
+		assert.True(t, conway.IsBorn(3))
+		assert.False(t, conway.IsBorn(4))
+	})
+
+	t.Run("should return false for out of range neighbor count", func(t *testing.T) {
+		conway := rule.Default()
+
+		assert.False(t, conway.IsBorn(-1))
+		assert.False(t, conway.IsBorn(9))
+	})
+}
+
+func TestIsSurvived(t *testing.T) {
+	t.Run("should return true only for survival neighbor count", func(t *testing.T) {
+		conway := rule.Default()
+
+		assert.False(t, conway.IsSurvived(1))
+		assert.True(t, conway.IsSurvived(2))
+		assert.True(t, conway.IsSurvived(3))
+		assert.False(t, conway.IsSurvived(4))
+	})
+}
+
+func TestGenerations(t *testing.T) {
+	t.Run("should return nil and error for invalid number of states", func(t *testing.T) {
+		var expectedError = rule.InvalidNumOfStatesError
+		notations := []string{"B2/S345/C1", "B2/S345/C37"}
+
+		for _, notation := range notations {
+			actualRule, actualError := rule.New(notation)
+
+			assert.Nil(t, actualRule)
+			assert.EqualError(t, actualError, expectedError)
+		}
+	})
+
+	t.Run("should return nil and error for states part that is not a number", func(t *testing.T) {
+		var expectedError = rule.InvalidNotationError
+
+		actualRule, actualError := rule.New("B2/S345/CX")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should accept generations notations", func(t *testing.T) {
+		testCases := []struct {
+			notation            string
+			expectedNotation    string
+			expectedNumOfStates int
+		}{
+			{"B2/S345/C4", "B2/S345/C4", 4},
+			{"345/2/4", "B2/S345/C4", 4},
+			{"/2/3", "B2/S/C3", 3},
+			{"B2/S/G3", "B2/S/C3", 3},
+			{"B3/S23/C2", "B3/S23", 2},
+		}
+
+		for _, testCase := range testCases {
+			actualRule, actualError := rule.New(testCase.notation)
+
+			assert.Nil(t, actualError)
+			assert.Equal(t, testCase.expectedNotation, actualRule.String())
+			assert.Equal(t, testCase.expectedNumOfStates, actualRule.GetNumOfStates())
+		}
+	})
+
+	t.Run("should return two states for life-like rule", func(t *testing.T) {
+		actualRule := rule.Default()
+
+		assert.Equal(t, 2, actualRule.GetNumOfStates())
+	})
+}
+
+func TestGetNextState(t *testing.T) {
+	t.Run("should return next state of life-like rule", func(t *testing.T) {
+		lifeRule := rule.Default()
+
+		assert.Equal(t, rule.Alive, lifeRule.GetNextState(rule.Dead, 3))
+		assert.Equal(t, rule.Dead, lifeRule.GetNextState(rule.Dead, 2))
+		assert.Equal(t, rule.Alive, lifeRule.GetNextState(rule.Alive, 2))
+		assert.Equal(t, rule.Dead, lifeRule.GetNextState(rule.Alive, 4))
+	})
+
+	t.Run("should return decaying states of generations rule", func(t *testing.T) {
+		briansBrain, _ := rule.New("/2/3")
+
+		assert.Equal(t, rule.Alive, briansBrain.GetNextState(rule.Dead, 2))
+		assert.Equal(t, uint8(2), briansBrain.GetNextState(rule.Alive, 2))
+		assert.Equal(t, rule.Dead, briansBrain.GetNextState(2, 2))
+	})
+}
+
+func TestHensel(t *testing.T) {
+	t.Run("should return nil and error for invalid letters", func(t *testing.T) {
+		var expectedError = rule.InvalidNotationError
+		notations := []string{"B2x/S23", "B1k/S23", "B3/S0c", "B2-/S23", "B-a/S23"}
+
+		for _, notation := range notations {
+			actualRule, actualError := rule.New(notation)
+
+			assert.Nil(t, actualRule, notation)
+			assert.EqualError(t, actualError, expectedError, notation)
+		}
+	})
+
+	t.Run("should return canonical notation", func(t *testing.T) {
+		testCases := []struct {
+			notation         string
+			expectedNotation string
+		}{
+			{"B2-a/S12", "B2-a/S12"},
+			{"b2ceikn/s12", "B2-a/S12"},
+			{"B3/S23-a", "B3/S23-a"},
+			{"B3aceijknqry/S2aceikn3", "B3/S23"},
+			{"B2ae3aijr/S23-a4itz", "B2ae3aijr/S23-a4itz"},
+			{"B2-a/S12/C3", "B2-a/S12/C3"},
+			{"B36/S125-c8", "B36/S125-c8"},
+		}
+
+		for _, testCase := range testCases {
+			actualRule, actualError := rule.New(testCase.notation)
+
+			assert.Nil(t, actualError, testCase.notation)
+			assert.Equal(t, testCase.expectedNotation, actualRule.String())
+		}
+	})
+
+	t.Run("should return totalistic only when every letter is set or unset", func(t *testing.T) {
+		totalistic, _ := rule.New("B3aceijknqry/S2aceikn3")
+		nonTotalistic, _ := rule.New("B2-a/S12")
+
+		assert.True(t, totalistic.IsTotalistic())
+		assert.True(t, rule.Default().IsTotalistic())
+		assert.False(t, nonTotalistic.IsTotalistic())
+	})
+
+	t.Run("should return next state by which neighbors are alive", func(t *testing.T) {
+		nonTotalistic, _ := rule.New("B2-a/S12")
+		var adjacentCornerAndEdge uint8 = 1<<0 | 1<<1
+		var cornersOnOneSide uint8 = 1<<0 | 1<<2
+		var oppositeEdges uint8 = 1<<1 | 1<<6
+
+		assert.Equal(t, rule.Dead, nonTotalistic.GetNextStateByNeighborhood(rule.Dead, adjacentCornerAndEdge))
+		assert.Equal(t, rule.Alive, nonTotalistic.GetNextStateByNeighborhood(rule.Dead, cornersOnOneSide))
+		assert.Equal(t, rule.Alive, nonTotalistic.GetNextStateByNeighborhood(rule.Alive, oppositeEdges))
+		assert.False(t, nonTotalistic.IsBorn(2))
+		assert.True(t, nonTotalistic.IsSurvived(2))
+	})
+
+	t.Run("should return same next state for every rotation and reflection", func(t *testing.T) {
+		nonTotalistic, _ := rule.New("B2ae3aijr/S23-a4itz")
+
+		for neighborhood := 0; neighborhood < rule.NumOfNeighborhoods; neighborhood++ {
+			var mirrored uint8
+			for bit, mirroredBit := range [8]uint{2, 1, 0, 4, 3, 7, 6, 5} {
+				if neighborhood&(1<<uint(bit)) != 0 {
+					mirrored |= 1 << mirroredBit
+				}
+			}
+
+			for _, state := range []uint8{rule.Dead, rule.Alive} {
+				assert.Equal(t,
+					nonTotalistic.GetNextStateByNeighborhood(state, uint8(neighborhood)),
+					nonTotalistic.GetNextStateByNeighborhood(state, mirrored))
+			}
+		}
+	})
+}
+
+func TestNeighborhood(t *testing.T) {
+	t.Run("should return nil and error for hensel letters on other neighborhoods", func(t *testing.T) {
+		var expectedError = rule.NeighborhoodLettersError
+
+		actualRule, actualError := rule.New("B2a/S13V")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for count beyond neighborhood", func(t *testing.T) {
+		var expectedError = rule.InvalidNotationError
+		notations := []string{"B5/S1V", "B7/S1H", "B5/S1W010101010"}
+
+		for _, notation := range notations {
+			actualRule, actualError := rule.New(notation)
+
+			assert.Nil(t, actualRule, notation)
+			assert.EqualError(t, actualError, expectedError, notation)
+		}
+	})
+
+	t.Run("should return nil and error for birth on zero neighbor", func(t *testing.T) {
+		var expectedError = rule.BirthOnZeroError
+
+		actualRule, actualError := rule.New("B0/S1V")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should accept neighborhood suffixes", func(t *testing.T) {
+		testCases := []struct {
+			notation             string
+			expectedNotation     string
+			expectedNeighborhood string
+			expectedRadius       int
+		}{
+			{"B3/S23", "B3/S23", rule.Moore, 1},
+			{"B2/S013V", "B2/S013V", rule.VonNeumann, 1},
+			{"b2/s34h", "B2/S34H", rule.Hexagonal, 1},
+			{"B2/S34/C3H", "B2/S34/C3H", rule.Hexagonal, 1},
+			{"B3/S23W111101111", "B3/S23W111101111", rule.Weighted, 1},
+			{"B10,12/S3,11W2222222222222222222222222", "B10,12/S3,11W2222222222222222222222222", rule.Weighted, 2},
+		}
+
+		for _, testCase := range testCases {
+			actualRule, actualError := rule.New(testCase.notation)
+
+			assert.Nil(t, actualError, testCase.notation)
+			assert.Equal(t, testCase.expectedNotation, actualRule.String())
+			assert.Equal(t, testCase.expectedNeighborhood, actualRule.GetNeighborhood())
+			assert.Equal(t, testCase.expectedRadius, actualRule.GetRadius())
+		}
+	})
+
+	t.Run("should return weights only for weighted rule", func(t *testing.T) {
+		weighted, _ := rule.New("B2/S3W121202121")
+		expectedWeights := [][]int{{1, 2, 1}, {2, 0, 2}, {1, 2, 1}}
+
+		assert.EqualValues(t, expectedWeights, weighted.GetWeights())
+		assert.Nil(t, rule.Default().GetWeights())
+	})
+
+	t.Run("should count only von neumann neighbors", func(t *testing.T) {
+		vonNeumann, _ := rule.New("B1/SV")
+		var corner uint8 = 1 << 0
+		var edge uint8 = 1 << 1
+
+		assert.Equal(t, rule.Dead, vonNeumann.GetNextStateByNeighborhood(rule.Dead, corner))
+		assert.Equal(t, rule.Alive, vonNeumann.GetNextStateByNeighborhood(rule.Dead, edge))
+		assert.True(t, vonNeumann.IsBorn(1))
+		assert.False(t, vonNeumann.IsTotalistic())
+	})
+}
+
+func TestLargerThanLife(t *testing.T) {
+	t.Run("should accept larger than life notation", func(t *testing.T) {
+		testCases := []struct {
+			notation         string
+			expectedNotation string
+			expectedRadius   int
+			expectedStates   int
+		}{
+			{"R5,C0,M1,S34..58,B34..45,NM", "R5,C0,M1,S34..58,B34..45,NM", 5, 2},
+			{"r1,c2,m0,s2..3,b3..3,nm", "R1,C0,M0,S2..3,B3..3,NM", 1, 2},
+			{"R10,C4,M0,S1..20,B5..9,NN", "R10,C4,M0,S1..20,B5..9,NN", 10, 4},
+		}
+
+		for _, testCase := range testCases {
+			actualRule, actualError := rule.New(testCase.notation)
+
+			assert.Nil(t, actualError, testCase.notation)
+			assert.True(t, actualRule.IsLargerThanLife())
+			assert.Equal(t, testCase.expectedNotation, actualRule.String())
+			assert.Equal(t, testCase.expectedRadius, actualRule.GetRadius())
+			assert.Equal(t, testCase.expectedStates, actualRule.GetNumOfStates())
+		}
+	})
+
+	t.Run("should return nil and error for invalid larger than life notation", func(t *testing.T) {
+		var expectedError = rule.InvalidLargerThanLifeError
+		notations := []string{
+			"R5,C0,M1,S34..58,B34..45",
+			"R0,C0,M1,S34..58,B34..45,NM",
+			"R501,C0,M1,S34..58,B34..45,NM",
+			"R5,C0,M2,S34..58,B34..45,NM",
+			"R5,C0,M1,S58..34,B34..45,NM",
+			"R5,C0,M1,S34..58,B34..45,NX",
+			"R1,C0,M0,S2..9,B3..3,NM",
+			"R1,C0,M1,S2..3,B3,NM",
+			"R5,M1,C0,S34..58,B34..45,NM",
+		}
+
+		for _, notation := range notations {
+			actualRule, actualError := rule.New(notation)
+
+			assert.Nil(t, actualRule, notation)
+			assert.EqualError(t, actualError, expectedError, notation)
+		}
+	})
+
+	t.Run("should return nil and error for invalid number of states", func(t *testing.T) {
+		var expectedError = rule.InvalidNumOfStatesError
+
+		actualRule, actualError := rule.New("R5,C37,M1,S34..58,B34..45,NM")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for birth on zero neighbor", func(t *testing.T) {
+		var expectedError = rule.BirthOnZeroError
+
+		actualRule, actualError := rule.New("R2,C0,M0,S1..2,B0..2,NM")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should follow counts within ranges", func(t *testing.T) {
+		bosco, _ := rule.New("R5,C0,M1,S34..58,B34..45,NM")
+
+		assert.True(t, bosco.IsCenterIncluded())
+		assert.True(t, bosco.IsBorn(34))
+		assert.False(t, bosco.IsBorn(46))
+		assert.Equal(t, rule.Alive, bosco.GetNextState(rule.Alive, 58))
+		assert.Equal(t, rule.Dead, bosco.GetNextState(rule.Alive, 59))
+	})
+
+	t.Run("should not be larger than life for other notations", func(t *testing.T) {
+		assert.False(t, rule.Default().IsLargerThanLife())
+		assert.Equal(t, 1, rule.Default().GetRadius())
+	})
+}
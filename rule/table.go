@@ -0,0 +1,590 @@
+package rule
+
+import (
+	"bufio"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/irainia/gameoflife-go/io"
+)
+
+const (
+	RuleFileExtension = ".rule"
+
+	WireWorld = "WireWorld"
+)
+
+const (
+	NotFoundRuleFileError = "rule file is not found"
+	NoTableError          = "rule file has no @TABLE section"
+	InvalidTableError     = "rule table is invalid (use: n_states, neighborhood, symmetries, var and transition lines)"
+	InvalidColorsError    = "rule colors are invalid (use: [state] [red] [green] [blue])"
+
+	InvalidNumOfTableStatesError = "number of states of rule table is invalid (should be from 2 to 256)"
+)
+
+var (
+	ErrNotFoundRuleFile = errors.New(NotFoundRuleFileError)
+	ErrNoTable          = errors.New(NoTableError)
+	ErrInvalidTable     = errors.New(InvalidTableError)
+	ErrInvalidColors    = errors.New(InvalidColorsError)
+
+	ErrInvalidNumOfTableStates = errors.New(InvalidNumOfTableStatesError)
+)
+
+const (
+	sectionPrefix  = "@"
+	ruleSection    = "@RULE"
+	tableSection   = "@TABLE"
+	colorsSection  = "@COLORS"
+	commentPrefix  = "#"
+	keySeparator   = ":"
+	variablePrefix = "var "
+	setStart       = "{"
+	setEnd         = "}"
+	entrySeparator = ","
+
+	numOfStatesKey  = "n_states"
+	neighborhoodKey = "neighborhood"
+	symmetriesKey   = "symmetries"
+
+	maxColor = 255
+
+	// MaxNumOfTableStates is the most states of a rule table, as in Golly.
+	MaxNumOfTableStates = 256
+
+	// maxNumOfTableCells is the cell with the most neighbors a transition
+	// is looked up with.
+	maxNumOfTableCells = 9
+)
+
+// neighborOrders lists the neighbors of each table neighborhood clockwise
+// from north, in the order of a transition line. The hexagonal one is on
+// the square grid sheared to the right, as for the H suffix.
+var neighborOrders = map[string][][2]int{
+	"moore":      {{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}},
+	"vonneumann": {{-1, 0}, {0, 1}, {1, 0}, {0, -1}},
+	"hexagonal":  {{-1, 0}, {0, 1}, {1, 1}, {1, 0}, {0, -1}, {-1, -1}},
+}
+
+var neighborhoodNames = map[string]string{
+	"moore":      Moore,
+	"vonneumann": VonNeumann,
+	"hexagonal":  Hexagonal,
+}
+
+// symmetryRotations gives the number of rotations of each symmetry, the
+// ones ending in reflect also being taken mirrored.
+var symmetryRotations = map[string]int{
+	"none":               1,
+	"reflect_horizontal": 1,
+	"rotate2":            2,
+	"rotate3":            3,
+	"rotate4":            4,
+	"rotate4reflect":     4,
+	"rotate6":            6,
+	"rotate6reflect":     6,
+	"rotate8":            8,
+	"rotate8reflect":     8,
+}
+
+const (
+	permuteSymmetry   = "permute"
+	reflectSymmetry   = "reflect"
+	horizontalReflect = "reflect_horizontal"
+)
+
+const wireWorldTable = `@RULE WireWorld
+@TABLE
+n_states:4
+neighborhood:Moore
+symmetries:permute
+var a={0,1,2,3}
+var b={a}
+var c={a}
+var d={a}
+var e={a}
+var f={a}
+var g={a}
+var h={a}
+var i={0,2,3}
+var j={i}
+var k={i}
+var l={i}
+var m={i}
+var n={i}
+var o={i}
+# an electron head becomes a tail and a tail becomes a conductor
+1,a,b,c,d,e,f,g,h,2
+2,a,b,c,d,e,f,g,h,3
+# a conductor becomes a head next to one or two heads
+3,1,i,j,k,l,m,n,o,1
+3,1,1,j,k,l,m,n,o,1
+@COLORS
+0 48 48 48
+1 0 128 255
+2 255 255 255
+3 255 128 0
+`
+
+// builtInTables holds the tables New knows by name, keyed in upper case.
+var builtInTables = map[string]string{
+	strings.ToUpper(WireWorld): wireWorldTable,
+}
+
+// entry is one cell of a transition: the states it matches and, for a
+// variable, the name every other use of it in the line is bound to.
+type entry struct {
+	states   []bool
+	variable string
+}
+
+type transition struct {
+	inputs []entry
+	output entry
+}
+
+// table is a Golly rule table. Transitions are tried in order and a cell
+// that none matches keeps its state. Looked up neighborhoods are cached,
+// as the same few come up over and over.
+type table struct {
+	name         string
+	neighbors    [][2]int
+	permutations [][]int
+	isPermuted   bool
+	transitions  []transition
+	colors       map[uint8][3]uint8
+
+	mutex sync.Mutex
+	cache map[[maxNumOfTableCells]uint8]uint8
+}
+
+// Load is New that also reads a Golly rule file when notation is a path
+// ending in RuleFileExtension.
+func Load(notation string) (*Rule, error) {
+	if filepath.Ext(notation) != RuleFileExtension {
+		return New(notation)
+	}
+
+	content, err := ioutil.ReadFile(notation)
+	if os.IsNotExist(err) {
+		return nil, &io.FileError{Path: notation, Err: ErrNotFoundRuleFile, Cause: err}
+	}
+	if err != nil {
+		return nil, &io.FileError{Path: notation, Err: io.ErrUnreadableFile, Cause: err}
+	}
+
+	rule, err := ParseTable(string(content))
+	if err != nil {
+		return nil, err
+	}
+	rule.notation = notation
+	return rule, nil
+}
+
+// ParseTable reads the @TABLE and @COLORS sections of a Golly rule file in
+// any order, skipping the other sections.
+func ParseTable(content string) (*Rule, error) {
+	var rule Rule
+	var ruleTable = table{
+		colors: make(map[uint8][3]uint8),
+		cache:  make(map[[maxNumOfTableCells]uint8]uint8),
+	}
+	var variables = make(map[string][]bool)
+
+	var section string
+	var symmetries string
+	var isTableFound bool
+	var colorLines []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, sectionPrefix) {
+			fields := strings.Fields(line)
+			section = fields[0]
+			if section == ruleSection && len(fields) > 1 {
+				ruleTable.name = fields[1]
+			}
+			isTableFound = isTableFound || section == tableSection
+			continue
+		}
+		if index := strings.Index(line, commentPrefix); index >= 0 {
+			line = strings.TrimSpace(line[:index])
+		}
+		if line == "" {
+			continue
+		}
+
+		var err error
+		switch section {
+		case tableSection:
+			err = ruleTable.parseLine(line, &rule, &symmetries, variables)
+		case colorsSection:
+			colorLines = append(colorLines, line)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !isTableFound {
+		return nil, ErrNoTable
+	}
+	if rule.numOfStates == 0 || ruleTable.neighbors == nil {
+		return nil, ErrInvalidTable
+	}
+	if err := ruleTable.setSymmetries(symmetries); err != nil {
+		return nil, err
+	}
+	// the colors are read once the number of states is known
+	for _, line := range colorLines {
+		if err := ruleTable.parseColor(line, rule.numOfStates); err != nil {
+			return nil, err
+		}
+	}
+
+	rule.table = &ruleTable
+	rule.notation = ruleTable.name
+	rule.radius = 1
+	return &rule, nil
+}
+
+func (ruleTable *table) parseLine(line string, rule *Rule, symmetries *string, variables map[string][]bool) error {
+	switch {
+	case strings.HasPrefix(line, variablePrefix):
+		if rule.numOfStates == 0 {
+			return ErrInvalidTable
+		}
+		parts := strings.SplitN(line[len(variablePrefix):], "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" || isDigits(name) {
+			return ErrInvalidTable
+		}
+		states, isValid := parseSet(strings.TrimSpace(parts[1]), rule.numOfStates, variables)
+		if !isValid {
+			return ErrInvalidTable
+		}
+		variables[name] = states
+	case strings.Contains(line, keySeparator):
+		parts := strings.SplitN(line, keySeparator, 2)
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch key {
+		case numOfStatesKey:
+			numOfStates, err := strconv.Atoi(value)
+			if err != nil {
+				return ErrInvalidTable
+			}
+			if numOfStates < MinNumOfStates || numOfStates > MaxNumOfTableStates {
+				return ErrInvalidNumOfTableStates
+			}
+			rule.numOfStates = numOfStates
+		case neighborhoodKey:
+			neighbors, isFound := neighborOrders[strings.ToLower(value)]
+			if !isFound {
+				return ErrInvalidTable
+			}
+			ruleTable.neighbors = neighbors
+			rule.neighborhood = neighborhoodNames[strings.ToLower(value)]
+		case symmetriesKey:
+			*symmetries = strings.ToLower(value)
+		default:
+			return ErrInvalidTable
+		}
+	default:
+		if rule.numOfStates == 0 || ruleTable.neighbors == nil {
+			return ErrInvalidTable
+		}
+		parsedTransition, isValid := parseTransition(line, len(ruleTable.neighbors), rule.numOfStates, variables)
+		if !isValid {
+			return ErrInvalidTable
+		}
+		ruleTable.transitions = append(ruleTable.transitions, parsedTransition)
+	}
+
+	return nil
+}
+
+func (ruleTable *table) parseColor(line string, numOfStates int) error {
+	fields := strings.Fields(line)
+	if len(fields) != 4 {
+		return ErrInvalidColors
+	}
+
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		value, err := strconv.Atoi(field)
+		if err != nil || value < 0 || value > maxColor {
+			return ErrInvalidColors
+		}
+		values[i] = value
+	}
+	if values[0] >= numOfStates {
+		return ErrInvalidColors
+	}
+
+	ruleTable.colors[uint8(values[0])] = [3]uint8{uint8(values[1]), uint8(values[2]), uint8(values[3])}
+	return nil
+}
+
+// setSymmetries lists the orders the neighbors of a transition are matched
+// in, each a rotation and maybe a reflection of the written order.
+func (ruleTable *table) setSymmetries(symmetries string) error {
+	numOfNeighbors := len(ruleTable.neighbors)
+	if symmetries == "" {
+		symmetries = "none"
+	}
+	if symmetries == permuteSymmetry {
+		ruleTable.isPermuted = true
+		return nil
+	}
+
+	numOfRotations, isFound := symmetryRotations[symmetries]
+	if !isFound || numOfNeighbors%numOfRotations != 0 {
+		return ErrInvalidTable
+	}
+	isReflected := symmetries == horizontalReflect || strings.HasSuffix(symmetries, reflectSymmetry)
+
+	step := numOfNeighbors / numOfRotations
+	for rotation := 0; rotation < numOfRotations; rotation++ {
+		rotated := make([]int, numOfNeighbors)
+		reflected := make([]int, numOfNeighbors)
+		for i := 0; i < numOfNeighbors; i++ {
+			rotated[i] = (i + rotation*step) % numOfNeighbors
+			reflected[i] = (numOfNeighbors - i + rotation*step) % numOfNeighbors
+		}
+		ruleTable.permutations = append(ruleTable.permutations, rotated)
+		if isReflected {
+			ruleTable.permutations = append(ruleTable.permutations, reflected)
+		}
+	}
+
+	return nil
+}
+
+// parseSet reads a state, a variable or a set of them in braces.
+func parseSet(text string, numOfStates int, variables map[string][]bool) ([]bool, bool) {
+	states := make([]bool, numOfStates)
+	if !strings.HasPrefix(text, setStart) {
+		return parseElement(text, states, variables)
+	}
+	if !strings.HasSuffix(text, setEnd) {
+		return nil, false
+	}
+
+	for _, element := range strings.Split(text[len(setStart):len(text)-len(setEnd)], entrySeparator) {
+		if _, isValid := parseElement(strings.TrimSpace(element), states, variables); !isValid {
+			return nil, false
+		}
+	}
+	return states, true
+}
+
+// parseElement adds the states of a state number or a variable to states.
+func parseElement(text string, states []bool, variables map[string][]bool) ([]bool, bool) {
+	if variable, isFound := variables[text]; isFound {
+		for state, isIncluded := range variable {
+			states[state] = states[state] || isIncluded
+		}
+		return states, true
+	}
+
+	state, err := strconv.Atoi(text)
+	if err != nil || !isDigits(text) || state >= len(states) {
+		return nil, false
+	}
+	states[state] = true
+	return states, true
+}
+
+// parseTransition reads the cell, its neighbors and the next state of the
+// cell, separated by ',' or, when every state is a digit, written together.
+func parseTransition(line string, numOfNeighbors, numOfStates int, variables map[string][]bool) (transition, bool) {
+	var texts []string
+	if strings.Contains(line, entrySeparator) {
+		texts = splitEntries(line)
+	} else {
+		texts = strings.Split(line, "")
+	}
+	if len(texts) != numOfNeighbors+2 {
+		return transition{}, false
+	}
+
+	var parsed transition
+	for i, text := range texts {
+		text = strings.TrimSpace(text)
+		states, isValid := parseSet(text, numOfStates, variables)
+		if !isValid {
+			return transition{}, false
+		}
+
+		parsedEntry := entry{states: states}
+		if _, isVariable := variables[text]; isVariable {
+			parsedEntry.variable = text
+		}
+		if i < len(texts)-1 {
+			parsed.inputs = append(parsed.inputs, parsedEntry)
+			continue
+		}
+
+		// the next state is a single state or a variable bound by the inputs
+		if parsedEntry.variable == "" && strings.HasPrefix(text, setStart) {
+			return transition{}, false
+		}
+		parsed.output = parsedEntry
+	}
+	if parsed.output.variable != "" && !parsed.isBound(parsed.output.variable) {
+		return transition{}, false
+	}
+
+	return parsed, true
+}
+
+// splitEntries splits line at the separators outside of braces.
+func splitEntries(line string) []string {
+	var entries []string
+	depth, start := 0, 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case setStart[0]:
+			depth++
+		case setEnd[0]:
+			depth--
+		case entrySeparator[0]:
+			if depth == 0 {
+				entries = append(entries, line[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(entries, line[start:])
+}
+
+func (parsed *transition) isBound(variable string) bool {
+	for _, input := range parsed.inputs {
+		if input.variable == variable {
+			return true
+		}
+	}
+	return false
+}
+
+// nextState returns the next state of states[0] with neighbors states[1:].
+func (ruleTable *table) nextState(states []uint8) uint8 {
+	var key [maxNumOfTableCells]uint8
+	copy(key[:], states)
+
+	ruleTable.mutex.Lock()
+	defer ruleTable.mutex.Unlock()
+	if nextState, isFound := ruleTable.cache[key]; isFound {
+		return nextState
+	}
+
+	nextState := states[0]
+	for i := range ruleTable.transitions {
+		if state, isMatched := ruleTable.match(&ruleTable.transitions[i], states); isMatched {
+			nextState = state
+			break
+		}
+	}
+	ruleTable.cache[key] = nextState
+	return nextState
+}
+
+func (ruleTable *table) match(candidate *transition, states []uint8) (uint8, bool) {
+	bindings := make(map[string]uint8)
+	if !bind(candidate.inputs[0], states[0], bindings) {
+		return 0, false
+	}
+
+	neighbors := states[1:]
+	if ruleTable.isPermuted {
+		isUsed := make([]bool, len(neighbors))
+		if !matchPermuted(candidate.inputs[1:], neighbors, isUsed, bindings) {
+			return 0, false
+		}
+		return candidate.outputState(bindings), true
+	}
+
+	for _, permutation := range ruleTable.permutations {
+		permutationBindings := map[string]uint8{}
+		for name, state := range bindings {
+			permutationBindings[name] = state
+		}
+
+		isMatched := true
+		for i, input := range candidate.inputs[1:] {
+			if !bind(input, neighbors[permutation[i]], permutationBindings) {
+				isMatched = false
+				break
+			}
+		}
+		if isMatched {
+			return candidate.outputState(permutationBindings), true
+		}
+	}
+
+	return 0, false
+}
+
+// matchPermuted tries every way of giving the neighbors to inputs.
+func matchPermuted(inputs []entry, neighbors []uint8, isUsed []bool, bindings map[string]uint8) bool {
+	if len(inputs) == 0 {
+		return true
+	}
+
+	for i, neighbor := range neighbors {
+		if isUsed[i] {
+			continue
+		}
+
+		_, wasBound := bindings[inputs[0].variable]
+		if !bind(inputs[0], neighbor, bindings) {
+			continue
+		}
+		isUsed[i] = true
+		if matchPermuted(inputs[1:], neighbors, isUsed, bindings) {
+			return true
+		}
+		isUsed[i] = false
+		if !wasBound {
+			delete(bindings, inputs[0].variable)
+		}
+	}
+
+	return false
+}
+
+// bind tells whether state matches input, binding the variable of input
+// to state on its first use.
+func bind(input entry, state uint8, bindings map[string]uint8) bool {
+	if !input.states[state] {
+		return false
+	}
+	if input.variable == "" {
+		return true
+	}
+	if bound, isBound := bindings[input.variable]; isBound {
+		return bound == state
+	}
+
+	bindings[input.variable] = state
+	return true
+}
+
+func (parsed *transition) outputState(bindings map[string]uint8) uint8 {
+	if parsed.output.variable != "" {
+		return bindings[parsed.output.variable]
+	}
+	for state, isIncluded := range parsed.output.states {
+		if isIncluded {
+			return uint8(state)
+		}
+	}
+	return Dead
+}
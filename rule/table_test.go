@@ -0,0 +1,231 @@
+package rule_test
+
+import (
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	lifeTable = `@RULE Life
+@TABLE
+n_states:2
+neighborhood:Moore
+symmetries:permute
+var a={0,1}
+var b={a}
+var c={a}
+var d={a}
+var e={a}
+var f={a}
+var g={a}
+var h={a}
+0,1,1,1,0,0,0,0,0,1
+1,1,1,0,0,0,0,0,0,1
+1,1,1,1,0,0,0,0,0,1
+1,a,b,c,d,e,f,g,h,0
+`
+	adjacentTable = `@RULE Adjacent
+@TABLE
+n_states:2
+neighborhood:Moore
+symmetries:rotate8reflect
+# born on two neighbors next to each other, an edge and a corner
+0,1,1,0,0,0,0,0,0,1
+@COLORS
+1 255 0 0
+`
+)
+
+func TestParseTable(t *testing.T) {
+	t.Run("should return nil and error for content without table", func(t *testing.T) {
+		var expectedError = rule.NoTableError
+
+		actualRule, actualError := rule.ParseTable("@RULE Empty\n@TREE\nnum_states=2\n")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid table", func(t *testing.T) {
+		var expectedError = rule.InvalidTableError
+		tables := []string{
+			"@TABLE\nneighborhood:Moore\nsymmetries:none\n",
+			"@TABLE\nn_states:2\nsymmetries:none\n",
+			"@TABLE\nn_states:2\nneighborhood:Triangular\n",
+			"@TABLE\nn_states:2\nneighborhood:Moore\nsymmetries:rotate3\n",
+			"@TABLE\nn_states:2\nneighborhood:Moore\nsymmetries:none\n0,1,1,1,0,0,0,0,0\n",
+			"@TABLE\nn_states:2\nneighborhood:Moore\nsymmetries:none\n0,1,1,2,0,0,0,0,0,1\n",
+			"@TABLE\nn_states:2\nneighborhood:Moore\nsymmetries:none\n0,1,1,z,0,0,0,0,0,1\n",
+			"@TABLE\nn_states:2\nneighborhood:vonNeumann\nsymmetries:none\nvar a={0,1}\n0,1,0,0,0,a\n",
+			"@TABLE\nn_states:2\nneighborhood:vonNeumann\nsymmetries:none\nvar a={0,2}\n",
+			"@TABLE\nn_states:2\nunknown:1\n",
+		}
+
+		for _, table := range tables {
+			actualRule, actualError := rule.ParseTable(table)
+
+			assert.Nil(t, actualRule, table)
+			assert.EqualError(t, actualError, expectedError, table)
+		}
+	})
+
+	t.Run("should return nil and error for invalid number of states", func(t *testing.T) {
+		var expectedError = rule.InvalidNumOfTableStatesError
+
+		actualRule, actualError := rule.ParseTable("@TABLE\nn_states:257\n")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should look up next state of table with as many states as golly", func(t *testing.T) {
+		table := "@TABLE\nn_states:256\nneighborhood:vonNeumann\nsymmetries:none\n" +
+			"255,0,0,0,0,254\n0,255,0,0,0,100\n0,0,255,0,0,200\n"
+
+		actualRule, actualError := rule.ParseTable(table)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 256, actualRule.GetNumOfStates())
+		assert.Equal(t, uint8(254), actualRule.GetNextStateByTable([]uint8{255, 0, 0, 0, 0}))
+		assert.Equal(t, uint8(100), actualRule.GetNextStateByTable([]uint8{0, 255, 0, 0, 0}))
+		assert.Equal(t, uint8(200), actualRule.GetNextStateByTable([]uint8{0, 0, 255, 0, 0}))
+		assert.Equal(t, uint8(0), actualRule.GetNextStateByTable([]uint8{0, 0, 0, 255, 0}))
+	})
+
+	t.Run("should read colors before table", func(t *testing.T) {
+		table := "@RULE Colored\n@COLORS\n1 255 0 0\n@TABLE\nn_states:2\nneighborhood:Moore\nsymmetries:permute\n"
+		var expectedColors = map[uint8][3]uint8{1: {255, 0, 0}}
+
+		actualRule, actualError := rule.ParseTable(table)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedColors, actualRule.GetColors())
+	})
+
+	t.Run("should return nil and error for invalid colors", func(t *testing.T) {
+		var expectedError = rule.InvalidColorsError
+		colors := []string{"1 255 0", "2 255 0 0", "1 256 0 0"}
+
+		for _, color := range colors {
+			actualRule, actualError := rule.ParseTable(lifeTable + "@COLORS\n" + color + "\n")
+
+			assert.Nil(t, actualRule, color)
+			assert.EqualError(t, actualError, expectedError, color)
+		}
+	})
+
+	t.Run("should look up next state by permuted neighbors", func(t *testing.T) {
+		life, _ := rule.ParseTable(lifeTable)
+
+		assert.True(t, life.IsTable())
+		assert.Equal(t, "Life", life.String())
+		assert.Equal(t, rule.Alive, life.GetNextStateByTable([]uint8{0, 0, 1, 0, 0, 1, 0, 1, 0}))
+		assert.Equal(t, rule.Dead, life.GetNextStateByTable([]uint8{0, 0, 1, 0, 0, 1, 0, 1, 1}))
+		assert.Equal(t, rule.Alive, life.GetNextStateByTable([]uint8{1, 1, 0, 0, 0, 0, 0, 0, 1}))
+		assert.Equal(t, rule.Dead, life.GetNextStateByTable([]uint8{1, 1, 0, 0, 0, 0, 0, 0, 0}))
+	})
+
+	t.Run("should look up next state by rotated and reflected neighbors", func(t *testing.T) {
+		adjacent, _ := rule.ParseTable(adjacentTable)
+
+		assert.Equal(t, rule.Alive, adjacent.GetNextStateByTable([]uint8{0, 0, 0, 0, 0, 1, 1, 0, 0}))
+		assert.Equal(t, rule.Alive, adjacent.GetNextStateByTable([]uint8{0, 1, 0, 0, 0, 0, 0, 0, 1}))
+		assert.Equal(t, rule.Dead, adjacent.GetNextStateByTable([]uint8{0, 1, 0, 1, 0, 0, 0, 0, 0}))
+		assert.Equal(t, rule.Alive, adjacent.GetNextStateByTable([]uint8{1, 0, 0, 0, 0, 0, 0, 0, 0}))
+	})
+
+	t.Run("should return colors of states", func(t *testing.T) {
+		adjacent, _ := rule.ParseTable(adjacentTable)
+		expectedColors := map[uint8][3]uint8{1: {255, 0, 0}}
+
+		assert.Equal(t, expectedColors, adjacent.GetColors())
+		assert.Empty(t, rule.Default().GetColors())
+	})
+
+	t.Run("should accept compact transitions and bound output", func(t *testing.T) {
+		table := "@TABLE\nn_states:3\nneighborhood:vonNeumann\nsymmetries:rotate4\nvar a={1,2}\n010002\na,0,0,0,0,a\n"
+
+		cellRule, err := rule.ParseTable(table)
+
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(2), cellRule.GetNextStateByTable([]uint8{0, 0, 0, 0, 1}))
+		assert.Equal(t, uint8(2), cellRule.GetNextStateByTable([]uint8{2, 0, 0, 0, 0}))
+		assert.Equal(t, uint8(1), cellRule.GetNextStateByTable([]uint8{1, 1, 0, 0, 0}))
+		assert.Equal(t, [][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}, cellRule.GetNeighbors())
+	})
+}
+
+func TestWireWorld(t *testing.T) {
+	t.Run("should return built-in wireworld", func(t *testing.T) {
+		wireWorld, err := rule.New("wireworld")
+
+		assert.Nil(t, err)
+		assert.Equal(t, rule.WireWorld, wireWorld.String())
+		assert.Equal(t, 4, wireWorld.GetNumOfStates())
+		assert.Len(t, wireWorld.GetColors(), 4)
+	})
+
+	t.Run("should follow wireworld transitions", func(t *testing.T) {
+		wireWorld, _ := rule.New(rule.WireWorld)
+
+		assert.Equal(t, uint8(2), wireWorld.GetNextStateByTable([]uint8{1, 3, 3, 3, 0, 0, 0, 0, 0}))
+		assert.Equal(t, uint8(3), wireWorld.GetNextStateByTable([]uint8{2, 1, 1, 1, 0, 0, 0, 0, 0}))
+		assert.Equal(t, uint8(1), wireWorld.GetNextStateByTable([]uint8{3, 0, 0, 1, 0, 2, 0, 3, 0}))
+		assert.Equal(t, uint8(1), wireWorld.GetNextStateByTable([]uint8{3, 0, 1, 1, 0, 0, 0, 0, 0}))
+		assert.Equal(t, uint8(3), wireWorld.GetNextStateByTable([]uint8{3, 1, 1, 1, 0, 0, 0, 0, 0}))
+		assert.Equal(t, uint8(3), wireWorld.GetNextStateByTable([]uint8{3, 2, 0, 3, 0, 0, 0, 0, 0}))
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should return rule of notation", func(t *testing.T) {
+		actualRule, actualError := rule.Load("B36/S23")
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, "B36/S23", actualRule.String())
+	})
+
+	t.Run("should return nil and error for missing rule file", func(t *testing.T) {
+		var expectedError = "./missing.rule: " + rule.NotFoundRuleFileError + ": no such file or directory"
+
+		actualRule, actualError := rule.Load("./missing.rule")
+
+		assert.Nil(t, actualRule)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return error telling missing rule file from invalid table", func(t *testing.T) {
+		_, missingError := rule.Load("./missing.rule")
+		_, invalidError := rule.ParseTable("@RULE Empty\n@TREE\nnum_states=2\n")
+
+		assert.True(t, errors.Is(missingError, rule.ErrNotFoundRuleFile))
+		assert.True(t, errors.Is(missingError, fs.ErrNotExist))
+		assert.True(t, errors.Is(invalidError, rule.ErrNoTable))
+		assert.False(t, errors.Is(invalidError, rule.ErrNotFoundRuleFile))
+	})
+
+	t.Run("should read rule file and keep its path as notation", func(t *testing.T) {
+		directory, err := ioutil.TempDir("", "rule")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "Life.rule")
+		if err = ioutil.WriteFile(path, []byte(lifeTable), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		actualRule, actualError := rule.Load(path)
+
+		assert.Nil(t, actualError)
+		assert.True(t, actualRule.IsTable())
+		assert.Equal(t, path, actualRule.String())
+	})
+}
@@ -0,0 +1,373 @@
+package sat
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	InvalidNumOfVariablesError = "number of variables is less than one (should be at least 1)"
+	InvalidLiteralError        = "literal is out of range (use: 1 to n for a variable and -1 to -n for its negation)"
+)
+
+var (
+	ErrInvalidNumOfVariables = errors.New(InvalidNumOfVariablesError)
+	ErrInvalidLiteral        = errors.New(InvalidLiteralError)
+)
+
+const (
+	unassigned int8 = -1
+	noReason        = -1
+
+	activityDecay    = 0.95
+	activityLimit    = 1e100
+	activityRescale  = 1e-100
+	firstRestart     = 100
+	restartGrowth    = 1.5
+	conflictsPerPoll = 256
+)
+
+// Solver decides whether clauses over numbered variables can all be true,
+// by conflict driven clause learning: unit propagation over two watched
+// literals per clause, a clause learnt from the first unique implication
+// point of every conflict, decisions on the most active variable and
+// restarts that grow longer each time.
+//
+// Literals are written as in DIMACS, v for variable v and -v for its
+// negation, and are stored as 2*(v-1) plus 1 when negated.
+type Solver struct {
+	numOfVariables int
+	clauses        [][]int
+	watches        [][]int
+	isUnsatisfied  bool
+
+	assigns  []int8
+	levels   []int
+	reasons  []int
+	phases   []bool
+	trail    []int
+	trailLim []int
+	head     int
+
+	activities []float64
+	increment  float64
+}
+
+func New(numOfVariables int) (*Solver, error) {
+	if numOfVariables < 1 {
+		return nil, ErrInvalidNumOfVariables
+	}
+
+	var solver = Solver{
+		numOfVariables: numOfVariables,
+		watches:        make([][]int, 2*numOfVariables),
+		assigns:        make([]int8, numOfVariables),
+		levels:         make([]int, numOfVariables),
+		reasons:        make([]int, numOfVariables),
+		phases:         make([]bool, numOfVariables),
+		activities:     make([]float64, numOfVariables),
+		increment:      1,
+	}
+	for i := 0; i < numOfVariables; i++ {
+		solver.assigns[i] = unassigned
+	}
+	return &solver, nil
+}
+
+func (solver *Solver) GetNumOfVariables() int {
+	return solver.numOfVariables
+}
+
+// AddClause adds the clause that at least one of literals is true. It can
+// be called between calls of Solve, e.g. to rule out a solution found.
+func (solver *Solver) AddClause(literals ...int) error {
+	clause := make([]int, 0, len(literals))
+	for _, literal := range literals {
+		if literal == 0 || literal > solver.numOfVariables || -literal > solver.numOfVariables {
+			return ErrInvalidLiteral
+		}
+		clause = append(clause, toLiteral(literal))
+	}
+
+	solver.backtrack(0)
+	if solver.isUnsatisfied {
+		return nil
+	}
+
+	simplified := make([]int, 0, len(clause))
+	for _, literal := range clause {
+		switch {
+		case solver.value(literal) == 1:
+			return nil
+		case solver.value(literal) == 0:
+			continue
+		case containsLiteral(simplified, literal^1):
+			return nil
+		case !containsLiteral(simplified, literal):
+			simplified = append(simplified, literal)
+		}
+	}
+
+	switch len(simplified) {
+	case 0:
+		solver.isUnsatisfied = true
+	case 1:
+		solver.assign(simplified[0], noReason)
+		if solver.propagate() != noReason {
+			solver.isUnsatisfied = true
+		}
+	default:
+		solver.attach(simplified)
+	}
+	return nil
+}
+
+// Solve tells whether the clauses can all be true, stopping with the error
+// of ctx when it is done first. GetValue then holds a solution.
+func (solver *Solver) Solve(ctx context.Context) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+	}
+	solver.backtrack(0)
+	if solver.isUnsatisfied {
+		return false, nil
+	}
+
+	numOfConflicts := 0
+	restartLimit := float64(firstRestart)
+	for {
+		conflict := solver.propagate()
+		if conflict != noReason {
+			numOfConflicts++
+			if numOfConflicts%conflictsPerPoll == 0 {
+				select {
+				case <-ctx.Done():
+					return false, ctx.Err()
+				default:
+				}
+			}
+			if len(solver.trailLim) == 0 {
+				solver.isUnsatisfied = true
+				return false, nil
+			}
+
+			learnt, level := solver.analyze(conflict)
+			solver.backtrack(level)
+			if len(learnt) == 1 {
+				solver.assign(learnt[0], noReason)
+			} else {
+				solver.assign(learnt[0], solver.attach(learnt))
+			}
+			solver.increment /= activityDecay
+			continue
+		}
+
+		if float64(numOfConflicts) >= restartLimit {
+			restartLimit *= restartGrowth
+			numOfConflicts = 0
+			solver.backtrack(0)
+			continue
+		}
+
+		variable := solver.pickVariable()
+		if variable < 0 {
+			return true, nil
+		}
+		solver.trailLim = append(solver.trailLim, len(solver.trail))
+		literal := 2 * variable
+		if !solver.phases[variable] {
+			literal++
+		}
+		solver.assign(literal, noReason)
+	}
+}
+
+// GetValue returns the value of variable in the solution of the last Solve.
+func (solver *Solver) GetValue(variable int) bool {
+	if variable < 1 || variable > solver.numOfVariables {
+		return false
+	}
+	return solver.assigns[variable-1] == 1
+}
+
+func toLiteral(literal int) int {
+	if literal < 0 {
+		return 2*(-literal-1) + 1
+	}
+	return 2 * (literal - 1)
+}
+
+func containsLiteral(clause []int, literal int) bool {
+	for _, other := range clause {
+		if other == literal {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns 1 for a true literal, 0 for a false one and -1 otherwise.
+func (solver *Solver) value(literal int) int8 {
+	assign := solver.assigns[literal>>1]
+	if assign == unassigned {
+		return unassigned
+	}
+	return assign ^ int8(literal&1)
+}
+
+func (solver *Solver) assign(literal, reason int) {
+	variable := literal >> 1
+	solver.assigns[variable] = int8(literal&1) ^ 1
+	solver.levels[variable] = len(solver.trailLim)
+	solver.reasons[variable] = reason
+	solver.trail = append(solver.trail, literal)
+}
+
+func (solver *Solver) attach(clause []int) int {
+	index := len(solver.clauses)
+	solver.clauses = append(solver.clauses, clause)
+	solver.watches[clause[0]] = append(solver.watches[clause[0]], index)
+	solver.watches[clause[1]] = append(solver.watches[clause[1]], index)
+	return index
+}
+
+// propagate assigns the last literal of every clause whose other literals
+// are false, returning the clause found false or noReason.
+func (solver *Solver) propagate() int {
+	for solver.head < len(solver.trail) {
+		falseLiteral := solver.trail[solver.head] ^ 1
+		solver.head++
+
+		watches := solver.watches[falseLiteral]
+		kept := 0
+		for i := 0; i < len(watches); i++ {
+			index := watches[i]
+			clause := solver.clauses[index]
+			if clause[0] == falseLiteral {
+				clause[0], clause[1] = clause[1], clause[0]
+			}
+			if solver.value(clause[0]) == 1 {
+				watches[kept] = index
+				kept++
+				continue
+			}
+
+			isMoved := false
+			for k := 2; k < len(clause); k++ {
+				if solver.value(clause[k]) != 0 {
+					clause[1], clause[k] = clause[k], clause[1]
+					solver.watches[clause[1]] = append(solver.watches[clause[1]], index)
+					isMoved = true
+					break
+				}
+			}
+			if isMoved {
+				continue
+			}
+
+			watches[kept] = index
+			kept++
+			if solver.value(clause[0]) == 0 {
+				kept += copy(watches[kept:], watches[i+1:])
+				solver.watches[falseLiteral] = watches[:kept]
+				solver.head = len(solver.trail)
+				return index
+			}
+			solver.assign(clause[0], index)
+		}
+		solver.watches[falseLiteral] = watches[:kept]
+	}
+
+	return noReason
+}
+
+// analyze learns the clause of conflict at the first unique implication
+// point and returns it with the level to go back to, where it is unit.
+func (solver *Solver) analyze(conflict int) ([]int, int) {
+	isSeen := make([]bool, solver.numOfVariables)
+	learnt := []int{0}
+	level := len(solver.trailLim)
+	numOfPending := 0
+	literal := -1
+	index := len(solver.trail) - 1
+	reason := conflict
+	for {
+		for _, other := range solver.clauses[reason] {
+			if other == literal {
+				continue
+			}
+			variable := other >> 1
+			if isSeen[variable] || solver.levels[variable] == 0 {
+				continue
+			}
+			isSeen[variable] = true
+			solver.bump(variable)
+			if solver.levels[variable] == level {
+				numOfPending++
+			} else {
+				learnt = append(learnt, other)
+			}
+		}
+
+		for !isSeen[solver.trail[index]>>1] {
+			index--
+		}
+		literal = solver.trail[index]
+		index--
+		reason = solver.reasons[literal>>1]
+		numOfPending--
+		if numOfPending == 0 {
+			break
+		}
+	}
+	learnt[0] = literal ^ 1
+
+	backLevel := 0
+	for i := 1; i < len(learnt); i++ {
+		if solver.levels[learnt[i]>>1] > backLevel {
+			backLevel = solver.levels[learnt[i]>>1]
+			learnt[1], learnt[i] = learnt[i], learnt[1]
+		}
+	}
+	return learnt, backLevel
+}
+
+func (solver *Solver) bump(variable int) {
+	solver.activities[variable] += solver.increment
+	if solver.activities[variable] > activityLimit {
+		for i := range solver.activities {
+			solver.activities[i] *= activityRescale
+		}
+		solver.increment *= activityRescale
+	}
+}
+
+// backtrack undoes the assignments above level, keeping their values as
+// the phase to try first next time.
+func (solver *Solver) backtrack(level int) {
+	if len(solver.trailLim) <= level {
+		return
+	}
+
+	for i := len(solver.trail) - 1; i >= solver.trailLim[level]; i-- {
+		variable := solver.trail[i] >> 1
+		solver.phases[variable] = solver.assigns[variable] == 1
+		solver.assigns[variable] = unassigned
+	}
+	solver.trail = solver.trail[:solver.trailLim[level]]
+	solver.trailLim = solver.trailLim[:level]
+	solver.head = len(solver.trail)
+}
+
+func (solver *Solver) pickVariable() int {
+	picked := -1
+	for variable := 0; variable < solver.numOfVariables; variable++ {
+		if solver.assigns[variable] == unassigned &&
+			(picked < 0 || solver.activities[variable] > solver.activities[picked]) {
+			picked = variable
+		}
+	}
+	return picked
+}
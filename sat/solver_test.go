@@ -0,0 +1,183 @@
+package sat_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/sat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for less than one variable", func(t *testing.T) {
+		var expectedError = sat.InvalidNumOfVariablesError
+
+		actualSolver, actualError := sat.New(0)
+
+		assert.Nil(t, actualSolver)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return solver of variables", func(t *testing.T) {
+		actualSolver, actualError := sat.New(3)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 3, actualSolver.GetNumOfVariables())
+	})
+}
+
+func TestAddClause(t *testing.T) {
+	t.Run("should return error for literal out of range", func(t *testing.T) {
+		var expectedError = sat.InvalidLiteralError
+		solver, _ := sat.New(2)
+		literals := [][]int{{0}, {3}, {1, -3}}
+
+		for _, clause := range literals {
+			actualError := solver.AddClause(clause...)
+
+			assert.EqualError(t, actualError, expectedError)
+		}
+	})
+}
+
+func TestSolve(t *testing.T) {
+	t.Run("should be unsatisfied by empty clause", func(t *testing.T) {
+		solver, _ := sat.New(1)
+		solver.AddClause()
+
+		isSatisfied, err := solver.Solve(context.Background())
+
+		assert.Nil(t, err)
+		assert.False(t, isSatisfied)
+	})
+
+	t.Run("should be unsatisfied by contradicting units", func(t *testing.T) {
+		solver, _ := sat.New(2)
+		solver.AddClause(1, 2)
+		solver.AddClause(-1)
+		solver.AddClause(-2)
+
+		isSatisfied, err := solver.Solve(context.Background())
+
+		assert.Nil(t, err)
+		assert.False(t, isSatisfied)
+	})
+
+	t.Run("should prove pigeons do not fit in fewer holes", func(t *testing.T) {
+		numOfPigeons, numOfHoles := 6, 5
+		solver, _ := sat.New(numOfPigeons * numOfHoles)
+		variable := func(pigeon, hole int) int { return pigeon*numOfHoles + hole + 1 }
+		for pigeon := 0; pigeon < numOfPigeons; pigeon++ {
+			clause := make([]int, 0, numOfHoles)
+			for hole := 0; hole < numOfHoles; hole++ {
+				clause = append(clause, variable(pigeon, hole))
+			}
+			solver.AddClause(clause...)
+		}
+		for hole := 0; hole < numOfHoles; hole++ {
+			for pigeon := 0; pigeon < numOfPigeons; pigeon++ {
+				for other := pigeon + 1; other < numOfPigeons; other++ {
+					solver.AddClause(-variable(pigeon, hole), -variable(other, hole))
+				}
+			}
+		}
+
+		isSatisfied, err := solver.Solve(context.Background())
+
+		assert.Nil(t, err)
+		assert.False(t, isSatisfied)
+	})
+
+	t.Run("should find assignment satisfying every clause", func(t *testing.T) {
+		random := rand.New(rand.NewSource(7))
+		numOfVariables := 60
+		solver, _ := sat.New(numOfVariables)
+		planted := make([]bool, numOfVariables+1)
+		for i := 1; i <= numOfVariables; i++ {
+			planted[i] = random.Intn(2) == 1
+		}
+		clauses := make([][]int, 0)
+		for len(clauses) < 250 {
+			clause := make([]int, 3)
+			isSatisfiedByPlanted := false
+			for k := range clause {
+				clause[k] = random.Intn(numOfVariables) + 1
+				if random.Intn(2) == 1 {
+					clause[k] = -clause[k]
+				}
+				isSatisfiedByPlanted = isSatisfiedByPlanted || (clause[k] > 0) == planted[abs(clause[k])]
+			}
+			if isSatisfiedByPlanted {
+				clauses = append(clauses, clause)
+				solver.AddClause(clause...)
+			}
+		}
+
+		isSatisfied, err := solver.Solve(context.Background())
+
+		assert.Nil(t, err)
+		assert.True(t, isSatisfied)
+		for _, clause := range clauses {
+			assert.True(t, solver.GetValue(abs(clause[0])) == (clause[0] > 0) ||
+				solver.GetValue(abs(clause[1])) == (clause[1] > 0) ||
+				solver.GetValue(abs(clause[2])) == (clause[2] > 0))
+		}
+	})
+
+	t.Run("should enumerate solutions by ruling out each one found", func(t *testing.T) {
+		solver, _ := sat.New(2)
+		solver.AddClause(1, 2)
+
+		numOfSolutions := 0
+		for {
+			isSatisfied, _ := solver.Solve(context.Background())
+			if !isSatisfied {
+				break
+			}
+			numOfSolutions++
+			blocking := []int{1, 2}
+			for i, variable := range blocking {
+				if solver.GetValue(variable) {
+					blocking[i] = -variable
+				}
+			}
+			solver.AddClause(blocking...)
+		}
+
+		assert.Equal(t, 3, numOfSolutions)
+	})
+
+	t.Run("should return error of done context", func(t *testing.T) {
+		numOfPigeons, numOfHoles := 11, 10
+		solver, _ := sat.New(numOfPigeons * numOfHoles)
+		for pigeon := 0; pigeon < numOfPigeons; pigeon++ {
+			clause := make([]int, 0, numOfHoles)
+			for hole := 0; hole < numOfHoles; hole++ {
+				clause = append(clause, pigeon*numOfHoles+hole+1)
+			}
+			solver.AddClause(clause...)
+		}
+		for hole := 0; hole < numOfHoles; hole++ {
+			for pigeon := 0; pigeon < numOfPigeons; pigeon++ {
+				for other := pigeon + 1; other < numOfPigeons; other++ {
+					solver.AddClause(-(pigeon*numOfHoles + hole + 1), -(other*numOfHoles + hole + 1))
+				}
+			}
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		isSatisfied, err := solver.Solve(ctx)
+
+		assert.False(t, isSatisfied)
+		assert.Equal(t, context.Canceled, err)
+	})
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
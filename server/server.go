@@ -0,0 +1,301 @@
+package server
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/io"
+	"github.com/irainia/gameoflife-go/io/file"
+	"github.com/irainia/gameoflife-go/rule"
+)
+
+const (
+	EmptyAddressError      = "address passed is empty"
+	MethodNotAllowedError  = "method is not allowed (use: POST)"
+	InvalidRequestError    = "request body is invalid"
+	NoLivingCellError      = "no living cell provided"
+	InvalidStepsError      = "steps should be between 1 and 1000"
+	TooLargePatternError   = "pattern should fit in 1024 by 1024 cells"
+	UnknownExportTypeError = "unknown export type (use: file)"
+)
+
+var (
+	ErrEmptyAddress      = errors.New(EmptyAddressError)
+	ErrMethodNotAllowed  = errors.New(MethodNotAllowedError)
+	ErrInvalidRequest    = errors.New(InvalidRequestError)
+	ErrNoLivingCell      = errors.New(NoLivingCellError)
+	ErrInvalidSteps      = errors.New(InvalidStepsError)
+	ErrTooLargePattern   = errors.New(TooLargePatternError)
+	ErrUnknownExportType = errors.New(UnknownExportTypeError)
+)
+
+const (
+	webDirectory = "web"
+
+	stepPath   = "/api/step"
+	exportPath = "/api/export"
+
+	minSteps       = 1
+	maxSteps       = 1000
+	maxExtent      = 1024
+	maxRequestSize = 1 << 20
+
+	exportTypeFile = "file"
+	exportFileName = "pattern" + file.FileExtension
+)
+
+//go:embed web
+var webContent embed.FS
+
+type (
+	stepRequest struct {
+		Rule  string   `json:"rule"`
+		Steps int      `json:"steps"`
+		Cells [][2]int `json:"cells"`
+	}
+
+	stepResponse struct {
+		Rule       string   `json:"rule"`
+		Cells      [][2]int `json:"cells"`
+		Population int      `json:"population"`
+	}
+
+	exportRequest struct {
+		Type  string   `json:"type"`
+		Cells [][2]int `json:"cells"`
+	}
+
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+type Server struct {
+	address string
+	handler http.Handler
+}
+
+func (server *Server) GetAddress() string {
+	return server.address
+}
+
+func (server *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	server.handler.ServeHTTP(writer, request)
+}
+
+func (server *Server) ListenAndServe() error {
+	return http.ListenAndServe(server.address, server.handler)
+}
+
+func New(address string) (*Server, error) {
+	if address == "" {
+		return nil, ErrEmptyAddress
+	}
+
+	webRoot, err := fs.Sub(webContent, webDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(webRoot)))
+	mux.HandleFunc(stepPath, handleStep)
+	mux.HandleFunc(exportPath, handleExport)
+
+	var server = Server{
+		address: address,
+		handler: mux,
+	}
+	return &server, nil
+}
+
+func handleStep(writer http.ResponseWriter, request *http.Request) {
+	var body stepRequest
+	if !decodeRequest(writer, request, &body) {
+		return
+	}
+	if body.Steps < minSteps || body.Steps > maxSteps {
+		writeError(writer, http.StatusBadRequest, ErrInvalidSteps)
+		return
+	}
+
+	cellRule := rule.Default()
+	if body.Rule != "" {
+		var err error
+		cellRule, err = rule.New(body.Rule)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	generation, originRow, originCol, err := cellsToGeneration(body.Cells)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	cellState, err := cell.NewWithRule(generation, cellRule)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	for i := 0; i < body.Steps; i++ {
+		cellState = cellState.GetNextState()
+	}
+
+	cells := stateToCells(cellState, originRow, originCol)
+	writeJSON(writer, http.StatusOK, stepResponse{
+		Rule:       cellRule.String(),
+		Cells:      cells,
+		Population: len(cells),
+	})
+}
+
+func handleExport(writer http.ResponseWriter, request *http.Request) {
+	var body exportRequest
+	if !decodeRequest(writer, request, &body) {
+		return
+	}
+
+	generation, _, _, err := cellsToGeneration(body.Cells)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	cellState, err := cell.New(generation)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	exportDirectory, err := ioutil.TempDir("", "gameoflife")
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, err)
+		return
+	}
+	defer os.RemoveAll(exportDirectory)
+
+	exportFilePath := filepath.Join(exportDirectory, exportFileName)
+	exportWriter, err := newExportWriter(body.Type, exportFilePath)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+	err = exportWriter.Write(cellState.GetGeneration())
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, err)
+		return
+	}
+
+	content, err := ioutil.ReadFile(exportFilePath)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, err)
+		return
+	}
+	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	writer.Header().Set("Content-Disposition", "attachment; filename=\""+exportFileName+"\"")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(content)
+}
+
+func newExportWriter(exportType string, path string) (io.Writer, error) {
+	switch exportType {
+	case "", exportTypeFile:
+		fileStream, err := file.New(path)
+		if err != nil {
+			return nil, err
+		}
+		return fileStream, nil
+	default:
+		return nil, ErrUnknownExportType
+	}
+}
+
+func decodeRequest(writer http.ResponseWriter, request *http.Request, body interface{}) bool {
+	if request.Method != http.MethodPost {
+		writeError(writer, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return false
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxRequestSize))
+	if err := decoder.Decode(body); err != nil {
+		writeError(writer, http.StatusBadRequest, ErrInvalidRequest)
+		return false
+	}
+
+	return true
+}
+
+func cellsToGeneration(cells [][2]int) ([][]bool, int, int, error) {
+	if len(cells) == 0 {
+		return nil, 0, 0, ErrNoLivingCell
+	}
+
+	minRow, maxRow := cells[0][0], cells[0][0]
+	minCol, maxCol := cells[0][1], cells[0][1]
+	for _, position := range cells {
+		if position[0] < minRow {
+			minRow = position[0]
+		}
+		if position[0] > maxRow {
+			maxRow = position[0]
+		}
+		if position[1] < minCol {
+			minCol = position[1]
+		}
+		if position[1] > maxCol {
+			maxCol = position[1]
+		}
+	}
+
+	// the differences are taken as unsigned so that coordinates far apart
+	// cannot overflow into a small box
+	if uint64(maxRow-minRow) >= maxExtent || uint64(maxCol-minCol) >= maxExtent {
+		return nil, 0, 0, ErrTooLargePattern
+	}
+
+	generation := make([][]bool, maxRow-minRow+1)
+	for i := 0; i < len(generation); i++ {
+		generation[i] = make([]bool, maxCol-minCol+1)
+	}
+	for _, position := range cells {
+		generation[position[0]-minRow][position[1]-minCol] = true
+	}
+
+	return generation, minRow, minCol, nil
+}
+
+func stateToCells(cellState *cell.CellState, originRow, originCol int) [][2]int {
+	rowOffset, colOffset := cellState.GetOffset()
+	generation := cellState.GetGeneration()
+
+	cells := make([][2]int, 0)
+	for i := 0; i < len(generation); i++ {
+		for j := 0; j < len(generation[i]); j++ {
+			if generation[i][j] {
+				cells = append(cells, [2]int{originRow + rowOffset + i, originCol + colOffset + j})
+			}
+		}
+	}
+
+	return cells
+}
+
+func writeError(writer http.ResponseWriter, status int, err error) {
+	writeJSON(writer, status, errorResponse{
+		Error: err.Error(),
+	})
+}
+
+func writeJSON(writer http.ResponseWriter, status int, body interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(body)
+}
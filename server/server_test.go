@@ -0,0 +1,184 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/rule"
+	"github.com/irainia/gameoflife-go/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(method, path, body string) *httptest.ResponseRecorder {
+	webServer, _ := server.New("localhost:0")
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	webServer.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func decodeError(recorder *httptest.ResponseRecorder) string {
+	var body struct {
+		Error string `json:"error"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&body)
+	return body.Error
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for empty address", func(t *testing.T) {
+		var expectedError = server.EmptyAddressError
+
+		actualServer, actualError := server.New("")
+
+		assert.Nil(t, actualServer)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return server and nil for valid address", func(t *testing.T) {
+		var expectedAddress = "localhost:8080"
+
+		actualServer, actualError := server.New(expectedAddress)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedAddress, actualServer.GetAddress())
+	})
+}
+
+func TestStatic(t *testing.T) {
+	t.Run("should serve embedded viewer", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/", "")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "<canvas")
+	})
+
+	t.Run("should serve embedded script", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/app.js", "")
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "/api/step")
+	})
+}
+
+func TestStep(t *testing.T) {
+	t.Run("should return error for non post method", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/api/step", "")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, server.MethodNotAllowedError, decodeError(recorder))
+	})
+
+	t.Run("should return error for invalid body", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/step", "{")
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, server.InvalidRequestError, decodeError(recorder))
+	})
+
+	t.Run("should return error for invalid steps", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/step", `{"steps":0,"cells":[[0,0]]}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, server.InvalidStepsError, decodeError(recorder))
+	})
+
+	t.Run("should return error for no living cell", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/step", `{"steps":1,"cells":[]}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, server.NoLivingCellError, decodeError(recorder))
+	})
+
+	t.Run("should return error for pattern larger than the maximum extent", func(t *testing.T) {
+		var bodies = []string{
+			`{"steps":1,"cells":[[0,0],[100000,100000]]}`,
+			`{"steps":1,"cells":[[0,0],[0,1024]]}`,
+			`{"steps":1,"cells":[[-9000000000000000000,0],[9000000000000000000,0]]}`,
+		}
+
+		for _, body := range bodies {
+			recorder := serve(http.MethodPost, "/api/step", body)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code, body)
+			assert.Equal(t, server.TooLargePatternError, decodeError(recorder), body)
+		}
+	})
+
+	t.Run("should step pattern as large as the maximum extent", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/step", `{"steps":1,"cells":[[0,0],[0,1],[1,0],[1,1],[1023,1023]]}`)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("should return error for invalid rule", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/step", `{"rule":"B9","steps":1,"cells":[[0,0]]}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, rule.InvalidNotationError, decodeError(recorder))
+	})
+
+	t.Run("should keep cell position across steps", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/step", `{"steps":1,"cells":[[10,9],[10,10],[10,11]]}`)
+		var body struct {
+			Rule       string   `json:"rule"`
+			Cells      [][2]int `json:"cells"`
+			Population int      `json:"population"`
+		}
+		var expectedCells = [][2]int{{9, 10}, {10, 10}, {11, 10}}
+
+		json.NewDecoder(recorder.Body).Decode(&body)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, rule.Conway, body.Rule)
+		assert.Equal(t, expectedCells, body.Cells)
+		assert.Equal(t, 3, body.Population)
+	})
+
+	t.Run("should apply the requested rule", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/step", `{"rule":"B2/S","steps":1,"cells":[[0,0],[0,1]]}`)
+		var body struct {
+			Rule  string   `json:"rule"`
+			Cells [][2]int `json:"cells"`
+		}
+		var expectedCells = [][2]int{{-1, 0}, {-1, 1}, {1, 0}, {1, 1}}
+
+		json.NewDecoder(recorder.Body).Decode(&body)
+
+		assert.Equal(t, "B2/S", body.Rule)
+		assert.Equal(t, expectedCells, body.Cells)
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Run("should return error for unknown export type", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/export", `{"type":"unknown","cells":[[0,0]]}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, server.UnknownExportTypeError, decodeError(recorder))
+	})
+
+	t.Run("should return error for pattern larger than the maximum extent", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/export", `{"type":"file","cells":[[0,0],[100000,100000]]}`)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, server.TooLargePatternError, decodeError(recorder))
+	})
+
+	t.Run("should return generation written by file writer", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/api/export", `{"type":"file","cells":[[5,6],[6,7],[7,5],[7,6],[7,7]]}`)
+		glider, _ := cell.New([][]bool{
+			{false, true, false},
+			{false, false, true},
+			{true, true, true},
+		})
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("Content-Disposition"), "pattern.cell")
+		assert.Equal(t, glider.String(), recorder.Body.String())
+	})
+}
@@ -0,0 +1,206 @@
+(function () {
+  "use strict";
+
+  var cellSize = 12;
+  var board = document.getElementById("board");
+  var context = board.getContext("2d");
+  var ruleInput = document.getElementById("rule");
+  var runButton = document.getElementById("run");
+  var speedInput = document.getElementById("speed");
+  var generationLabel = document.getElementById("generation");
+  var populationLabel = document.getElementById("population");
+  var messageLabel = document.getElementById("message");
+
+  var cells = new Map();
+  var generation = 0;
+  var running = false;
+  var drawing = null;
+
+  function key(row, col) {
+    return row + "," + col;
+  }
+
+  function setCells(list) {
+    cells = new Map();
+    list.forEach(function (position) {
+      cells.set(key(position[0], position[1]), position);
+    });
+  }
+
+  function listCells() {
+    return Array.from(cells.values());
+  }
+
+  function resize() {
+    board.width = window.innerWidth;
+    board.height = window.innerHeight - board.getBoundingClientRect().top;
+    render();
+  }
+
+  function render() {
+    context.fillStyle = "#fff";
+    context.fillRect(0, 0, board.width, board.height);
+
+    context.strokeStyle = "#eee";
+    context.beginPath();
+    for (var x = 0; x <= board.width; x += cellSize) {
+      context.moveTo(x + 0.5, 0);
+      context.lineTo(x + 0.5, board.height);
+    }
+    for (var y = 0; y <= board.height; y += cellSize) {
+      context.moveTo(0, y + 0.5);
+      context.lineTo(board.width, y + 0.5);
+    }
+    context.stroke();
+
+    context.fillStyle = "#222";
+    cells.forEach(function (position) {
+      context.fillRect(position[1] * cellSize + 1, position[0] * cellSize + 1, cellSize - 1, cellSize - 1);
+    });
+
+    generationLabel.textContent = generation;
+    populationLabel.textContent = cells.size;
+  }
+
+  function showMessage(text) {
+    messageLabel.textContent = text || "";
+  }
+
+  function post(path, body) {
+    return fetch(path, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(body)
+    }).then(function (response) {
+      if (response.ok) {
+        return response;
+      }
+      return response.json().then(function (result) {
+        throw new Error(result.error);
+      });
+    });
+  }
+
+  function step() {
+    if (cells.size === 0) {
+      stop();
+      return Promise.resolve();
+    }
+
+    return post("/api/step", { rule: ruleInput.value, steps: 1, cells: listCells() })
+      .then(function (response) {
+        return response.json();
+      })
+      .then(function (result) {
+        setCells(result.cells);
+        generation++;
+        showMessage("");
+        render();
+      })
+      .catch(function (error) {
+        stop();
+        showMessage(error.message);
+      });
+  }
+
+  function loop() {
+    if (!running) {
+      return;
+    }
+    step().then(function () {
+      if (running) {
+        setTimeout(loop, 1000 - speedInput.value);
+      }
+    });
+  }
+
+  function start() {
+    running = true;
+    runButton.textContent = "Pause";
+    loop();
+  }
+
+  function stop() {
+    running = false;
+    runButton.textContent = "Run";
+  }
+
+  function exportPattern() {
+    if (cells.size === 0) {
+      showMessage("nothing to export");
+      return;
+    }
+
+    post("/api/export", { type: "file", cells: listCells() })
+      .then(function (response) {
+        return response.blob();
+      })
+      .then(function (blob) {
+        var link = document.createElement("a");
+        link.href = URL.createObjectURL(blob);
+        link.download = "pattern.cell";
+        link.click();
+        URL.revokeObjectURL(link.href);
+        showMessage("");
+      })
+      .catch(function (error) {
+        showMessage(error.message);
+      });
+  }
+
+  function positionOf(event) {
+    var bounds = board.getBoundingClientRect();
+    return [
+      Math.floor((event.clientY - bounds.top) / cellSize),
+      Math.floor((event.clientX - bounds.left) / cellSize)
+    ];
+  }
+
+  function paint(event) {
+    var position = positionOf(event);
+    var cellKey = key(position[0], position[1]);
+    if (drawing) {
+      cells.set(cellKey, position);
+    } else {
+      cells.delete(cellKey);
+    }
+    render();
+  }
+
+  board.addEventListener("mousedown", function (event) {
+    var position = positionOf(event);
+    drawing = !cells.has(key(position[0], position[1]));
+    paint(event);
+  });
+  board.addEventListener("mousemove", function (event) {
+    if (drawing !== null) {
+      paint(event);
+    }
+  });
+  window.addEventListener("mouseup", function () {
+    drawing = null;
+  });
+
+  document.getElementById("step").addEventListener("click", function () {
+    stop();
+    step();
+  });
+  runButton.addEventListener("click", function () {
+    if (running) {
+      stop();
+    } else {
+      start();
+    }
+  });
+  document.getElementById("clear").addEventListener("click", function () {
+    stop();
+    cells = new Map();
+    generation = 0;
+    showMessage("");
+    render();
+  });
+  document.getElementById("export").addEventListener("click", exportPattern);
+  window.addEventListener("resize", resize);
+
+  resize();
+})();
@@ -0,0 +1,49 @@
+package simulation
+
+import (
+	"github.com/irainia/gameoflife-go/cell"
+)
+
+// history keeps the states of consecutive generations in a ring of fixed
+// capacity, dropping the oldest when full. A cell state is never changed
+// once made, so the states are kept as they are, without copies.
+type history struct {
+	states          []*cell.CellState
+	start           int
+	length          int
+	firstGeneration int
+}
+
+func newHistory(capacity int, generation int, cellState *cell.CellState) *history {
+	var kept = history{
+		states:          make([]*cell.CellState, capacity),
+		firstGeneration: generation,
+	}
+	kept.push(cellState)
+	return &kept
+}
+
+// getLastGeneration returns the newest generation kept.
+func (kept *history) getLastGeneration() int {
+	return kept.firstGeneration + kept.length - 1
+}
+
+// get returns the state of generation, nil when it is not kept.
+func (kept *history) get(generation int) *cell.CellState {
+	if generation < kept.firstGeneration || generation > kept.getLastGeneration() {
+		return nil
+	}
+	return kept.states[(kept.start+generation-kept.firstGeneration)%len(kept.states)]
+}
+
+// push keeps cellState as the generation after the newest.
+func (kept *history) push(cellState *cell.CellState) {
+	if kept.length == len(kept.states) {
+		kept.states[kept.start] = nil
+		kept.start = (kept.start + 1) % len(kept.states)
+		kept.firstGeneration++
+		kept.length--
+	}
+	kept.states[(kept.start+kept.length)%len(kept.states)] = cellState
+	kept.length++
+}
@@ -0,0 +1,246 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/irainia/gameoflife-go/cell"
+)
+
+const (
+	NilCellStateError       = "cell state passed is nil"
+	NegativeNumOfStepsError = "number of steps is negative"
+	NilObserverError        = "observer passed is nil"
+	NegativeGenerationError = "generation is negative"
+	InvalidHistorySizeError = "history size is less than one (should be at least 1)"
+	NotKeptGenerationError  = "generation is not kept in history (keep more with KeepHistory)"
+)
+
+var (
+	ErrNilCellState       = errors.New(NilCellStateError)
+	ErrNegativeNumOfSteps = errors.New(NegativeNumOfStepsError)
+	ErrNilObserver        = errors.New(NilObserverError)
+	ErrNegativeGeneration = errors.New(NegativeGenerationError)
+	ErrInvalidHistorySize = errors.New(InvalidHistorySizeError)
+	ErrNotKeptGeneration  = errors.New(NotKeptGenerationError)
+)
+
+const (
+	defaultHistorySize = 1
+	noGeneration       = -1
+)
+
+type Observer func(generation int, cellState *cell.CellState)
+
+type Simulation struct {
+	cellState  *cell.CellState
+	generation int
+
+	isStable         bool
+	isExtinct        bool
+	stableGeneration int
+
+	history *history
+
+	generationObservers []Observer
+	stableObservers     []Observer
+	extinctObservers    []Observer
+}
+
+func (simulation *Simulation) GetCellState() *cell.CellState {
+	return simulation.cellState
+}
+
+func (simulation *Simulation) GetGeneration() int {
+	return simulation.generation
+}
+
+func (simulation *Simulation) IsStable() bool {
+	return simulation.isStable
+}
+
+func (simulation *Simulation) IsExtinct() bool {
+	return simulation.isExtinct
+}
+
+func (simulation *Simulation) OnGeneration(observer Observer) error {
+	if observer == nil {
+		return ErrNilObserver
+	}
+	simulation.generationObservers = append(simulation.generationObservers, observer)
+	return nil
+}
+
+func (simulation *Simulation) OnStable(observer Observer) error {
+	if observer == nil {
+		return ErrNilObserver
+	}
+	simulation.stableObservers = append(simulation.stableObservers, observer)
+	return nil
+}
+
+func (simulation *Simulation) OnExtinct(observer Observer) error {
+	if observer == nil {
+		return ErrNilObserver
+	}
+	simulation.extinctObservers = append(simulation.extinctObservers, observer)
+	return nil
+}
+
+// GetHistoryRange returns the oldest and the newest generation kept, which
+// can be gone back and forth to without stepping.
+func (simulation *Simulation) GetHistoryRange() (int, int) {
+	return simulation.history.firstGeneration, simulation.history.getLastGeneration()
+}
+
+// KeepHistory keeps the states of the last size generations, the current
+// one included, so they can be gone back to. Only the current state is
+// kept by default.
+func (simulation *Simulation) KeepHistory(size int) error {
+	if size < 1 {
+		return ErrInvalidHistorySize
+	}
+
+	first := simulation.generation - size + 1
+	if first < simulation.history.firstGeneration {
+		first = simulation.history.firstGeneration
+	}
+	kept := newHistory(size, first, simulation.history.get(first))
+	for generation := first + 1; generation <= simulation.history.getLastGeneration() && kept.length < size; generation++ {
+		kept.push(simulation.history.get(generation))
+	}
+	simulation.history = kept
+	return nil
+}
+
+// Step goes to the next generation, taking its state from the history when
+// it is kept, e.g. after StepBack, and notifies the observers.
+func (simulation *Simulation) Step() *cell.CellState {
+	previousState := simulation.cellState
+	nextState := simulation.history.get(simulation.generation + 1)
+	if nextState == nil {
+		nextState = previousState.GetNextState()
+		simulation.history.push(nextState)
+	}
+	simulation.cellState = nextState
+	simulation.generation++
+
+	notify(simulation.generationObservers, simulation.generation, simulation.cellState)
+
+	if !simulation.isExtinct && isExtinct(simulation.cellState) {
+		simulation.isExtinct = true
+		notify(simulation.extinctObservers, simulation.generation, simulation.cellState)
+	}
+	if !simulation.isStable && !simulation.isExtinct && simulation.cellState.IsEqual(previousState) {
+		simulation.isStable = true
+		simulation.stableGeneration = simulation.generation
+		notify(simulation.stableObservers, simulation.generation, simulation.cellState)
+	}
+
+	return simulation.cellState
+}
+
+func (simulation *Simulation) StepN(numOfSteps int) (*cell.CellState, error) {
+	if numOfSteps < 0 {
+		return nil, ErrNegativeNumOfSteps
+	}
+
+	for i := 0; i < numOfSteps; i++ {
+		simulation.Step()
+	}
+
+	return simulation.cellState, nil
+}
+
+// StepNContext is StepN that stops early when ctx is done, returning the
+// latest cell state together with the context error.
+func (simulation *Simulation) StepNContext(ctx context.Context, numOfSteps int) (*cell.CellState, error) {
+	if numOfSteps < 0 {
+		return nil, ErrNegativeNumOfSteps
+	}
+
+	for i := 0; i < numOfSteps; i++ {
+		select {
+		case <-ctx.Done():
+			return simulation.cellState, ctx.Err()
+		default:
+		}
+		simulation.Step()
+	}
+
+	return simulation.cellState, nil
+}
+
+// Run steps until the pattern is extinct or stable, or until ctx is done,
+// in which case the context error is returned.
+func (simulation *Simulation) Run(ctx context.Context) error {
+	for !simulation.isExtinct && !simulation.isStable {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		simulation.Step()
+	}
+
+	return nil
+}
+
+// StepBack goes to the generation before, when it is kept in history.
+func (simulation *Simulation) StepBack() (*cell.CellState, error) {
+	return simulation.JumpTo(simulation.generation - 1)
+}
+
+// JumpTo goes to generation, back to a state kept in history or forward by
+// stepping, which notifies the observers of every generation stepped.
+func (simulation *Simulation) JumpTo(generation int) (*cell.CellState, error) {
+	if generation < 0 {
+		return nil, ErrNegativeGeneration
+	}
+	if generation > simulation.generation {
+		return simulation.StepN(generation - simulation.generation)
+	}
+
+	cellState := simulation.history.get(generation)
+	if cellState == nil {
+		return nil, ErrNotKeptGeneration
+	}
+	simulation.cellState = cellState
+	simulation.generation = generation
+	simulation.isExtinct = isExtinct(cellState)
+	simulation.isStable = simulation.stableGeneration != noGeneration && generation >= simulation.stableGeneration
+
+	return cellState, nil
+}
+
+func New(cellState *cell.CellState) (*Simulation, error) {
+	return NewFromGeneration(cellState, 0)
+}
+
+func NewFromGeneration(cellState *cell.CellState, generation int) (*Simulation, error) {
+	if cellState == nil {
+		return nil, ErrNilCellState
+	}
+	if generation < 0 {
+		return nil, ErrNegativeGeneration
+	}
+
+	var simulation = Simulation{
+		cellState:        cellState,
+		generation:       generation,
+		isExtinct:        isExtinct(cellState),
+		stableGeneration: noGeneration,
+		history:          newHistory(defaultHistorySize, generation, cellState),
+	}
+	return &simulation, nil
+}
+
+func notify(observers []Observer, generation int, cellState *cell.CellState) {
+	for _, observer := range observers {
+		observer(generation, cellState)
+	}
+}
+
+func isExtinct(cellState *cell.CellState) bool {
+	return len(cellState.GetGeneration()) == 0
+}
@@ -0,0 +1,390 @@
+package simulation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/simulation"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	blinkerGeneration = [][]bool{
+		{true, true, true},
+	}
+	blockGeneration = [][]bool{
+		{true, true},
+		{true, true},
+	}
+	dominoGeneration = [][]bool{
+		{true, true},
+	}
+	preBlockGeneration = [][]bool{
+		{true, true},
+		{true, false},
+	}
+)
+
+func newSimulation(generation [][]bool) *simulation.Simulation {
+	cellState, _ := cell.New(generation)
+	gameSimulation, _ := simulation.New(cellState)
+	return gameSimulation
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for nil cell state", func(t *testing.T) {
+		var expectedError = simulation.NilCellStateError
+
+		actualSimulation, actualError := simulation.New(nil)
+
+		assert.Nil(t, actualSimulation)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should start at generation zero", func(t *testing.T) {
+		cellState, _ := cell.New(blinkerGeneration)
+
+		actualSimulation, actualError := simulation.New(cellState)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 0, actualSimulation.GetGeneration())
+		assert.Equal(t, cellState, actualSimulation.GetCellState())
+	})
+}
+
+func TestNewFromGeneration(t *testing.T) {
+	t.Run("should return nil and error for negative generation", func(t *testing.T) {
+		cellState, _ := cell.New(blinkerGeneration)
+		var expectedError = simulation.NegativeGenerationError
+
+		actualSimulation, actualError := simulation.NewFromGeneration(cellState, -1)
+
+		assert.Nil(t, actualSimulation)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should continue counting from the given generation", func(t *testing.T) {
+		cellState, _ := cell.New(blinkerGeneration)
+		gameSimulation, _ := simulation.NewFromGeneration(cellState, 41)
+
+		gameSimulation.Step()
+
+		assert.Equal(t, 42, gameSimulation.GetGeneration())
+	})
+}
+
+func TestStep(t *testing.T) {
+	t.Run("should advance cell state and generation", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		var expectedGeneration [][]bool = [][]bool{
+			{true},
+			{true},
+			{true},
+		}
+
+		actualCellState := gameSimulation.Step()
+
+		assert.Equal(t, 1, gameSimulation.GetGeneration())
+		assert.EqualValues(t, expectedGeneration, actualCellState.GetGeneration())
+	})
+
+	t.Run("should notify generation observers in order", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		notified := make([]int, 0)
+		gameSimulation.OnGeneration(func(generation int, cellState *cell.CellState) {
+			notified = append(notified, generation)
+		})
+		gameSimulation.OnGeneration(func(generation int, cellState *cell.CellState) {
+			notified = append(notified, -generation)
+		})
+
+		gameSimulation.Step()
+		gameSimulation.Step()
+
+		assert.Equal(t, []int{1, -1, 2, -2}, notified)
+	})
+
+	t.Run("should notify extinct observer once", func(t *testing.T) {
+		gameSimulation := newSimulation(dominoGeneration)
+		notified := make([]int, 0)
+		gameSimulation.OnExtinct(func(generation int, cellState *cell.CellState) {
+			notified = append(notified, generation)
+		})
+
+		gameSimulation.Step()
+		gameSimulation.Step()
+
+		assert.Equal(t, []int{1}, notified)
+		assert.True(t, gameSimulation.IsExtinct())
+		assert.False(t, gameSimulation.IsStable())
+	})
+
+	t.Run("should notify stable observer once", func(t *testing.T) {
+		gameSimulation := newSimulation(preBlockGeneration)
+		notified := make([]int, 0)
+		gameSimulation.OnStable(func(generation int, cellState *cell.CellState) {
+			notified = append(notified, generation)
+		})
+
+		gameSimulation.Step()
+		gameSimulation.Step()
+		gameSimulation.Step()
+
+		assert.Equal(t, []int{2}, notified)
+		assert.True(t, gameSimulation.IsStable())
+		assert.EqualValues(t, blockGeneration, gameSimulation.GetCellState().GetGeneration())
+	})
+
+	t.Run("should not notify stable observer for oscillator", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		isNotified := false
+		gameSimulation.OnStable(func(generation int, cellState *cell.CellState) {
+			isNotified = true
+		})
+
+		gameSimulation.StepN(4)
+
+		assert.False(t, isNotified)
+	})
+}
+
+func TestOnGeneration(t *testing.T) {
+	t.Run("should return error for nil observer", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		var expectedError = simulation.NilObserverError
+
+		assert.EqualError(t, gameSimulation.OnGeneration(nil), expectedError)
+		assert.EqualError(t, gameSimulation.OnStable(nil), expectedError)
+		assert.EqualError(t, gameSimulation.OnExtinct(nil), expectedError)
+	})
+}
+
+func TestStepN(t *testing.T) {
+	t.Run("should return nil and error for negative steps", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		var expectedError = simulation.NegativeNumOfStepsError
+
+		actualCellState, actualError := gameSimulation.StepN(-1)
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should advance the given number of generations", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+
+		actualCellState, actualError := gameSimulation.StepN(4)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 4, gameSimulation.GetGeneration())
+		assert.EqualValues(t, blinkerGeneration, actualCellState.GetGeneration())
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should stop when pattern is stable", func(t *testing.T) {
+		gameSimulation := newSimulation(preBlockGeneration)
+
+		actualError := gameSimulation.Run(context.Background())
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 2, gameSimulation.GetGeneration())
+	})
+
+	t.Run("should stop when pattern is extinct", func(t *testing.T) {
+		gameSimulation := newSimulation(dominoGeneration)
+
+		actualError := gameSimulation.Run(context.Background())
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 1, gameSimulation.GetGeneration())
+	})
+
+	t.Run("should return context error when cancelled", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		ctx, cancel := context.WithCancel(context.Background())
+		gameSimulation.OnGeneration(func(generation int, cellState *cell.CellState) {
+			if generation == 10 {
+				cancel()
+			}
+		})
+
+		actualError := gameSimulation.Run(ctx)
+
+		assert.Equal(t, context.Canceled, actualError)
+		assert.Equal(t, 10, gameSimulation.GetGeneration())
+	})
+}
+
+func TestStepNContext(t *testing.T) {
+	t.Run("should return nil and error for negative steps", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		var expectedError = simulation.NegativeNumOfStepsError
+
+		actualCellState, actualError := gameSimulation.StepNContext(context.Background(), -1)
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should advance the given number of generations", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+
+		actualCellState, actualError := gameSimulation.StepNContext(context.Background(), 4)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 4, gameSimulation.GetGeneration())
+		assert.EqualValues(t, blinkerGeneration, actualCellState.GetGeneration())
+	})
+
+	t.Run("should return latest cell state and context error when cancelled", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		ctx, cancel := context.WithCancel(context.Background())
+		gameSimulation.OnGeneration(func(generation int, cellState *cell.CellState) {
+			if generation == 3 {
+				cancel()
+			}
+		})
+		var expectedGeneration [][]bool = [][]bool{
+			{true},
+			{true},
+			{true},
+		}
+
+		actualCellState, actualError := gameSimulation.StepNContext(ctx, 10)
+
+		assert.Equal(t, context.Canceled, actualError)
+		assert.Equal(t, 3, gameSimulation.GetGeneration())
+		assert.EqualValues(t, expectedGeneration, actualCellState.GetGeneration())
+	})
+}
+
+func TestKeepHistory(t *testing.T) {
+	t.Run("should return error for size less than one", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		var expectedError = simulation.InvalidHistorySizeError
+
+		actualError := gameSimulation.KeepHistory(0)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should keep the current generation only by default", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+
+		gameSimulation.StepN(5)
+
+		actualFirst, actualLast := gameSimulation.GetHistoryRange()
+		assert.Equal(t, 5, actualFirst)
+		assert.Equal(t, 5, actualLast)
+	})
+
+	t.Run("should keep the last generations up to size", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		gameSimulation.KeepHistory(3)
+
+		gameSimulation.StepN(5)
+
+		actualFirst, actualLast := gameSimulation.GetHistoryRange()
+		assert.Equal(t, 3, actualFirst)
+		assert.Equal(t, 5, actualLast)
+	})
+
+	t.Run("should keep the generations around the current one when resized", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		gameSimulation.KeepHistory(10)
+		gameSimulation.StepN(9)
+		gameSimulation.JumpTo(4)
+
+		actualError := gameSimulation.KeepHistory(3)
+
+		assert.Nil(t, actualError)
+		actualFirst, actualLast := gameSimulation.GetHistoryRange()
+		assert.Equal(t, 2, actualFirst)
+		assert.Equal(t, 4, actualLast)
+		assert.Equal(t, 4, gameSimulation.GetGeneration())
+	})
+}
+
+func TestStepBack(t *testing.T) {
+	t.Run("should return nil and error for generation not kept", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		gameSimulation.Step()
+		var expectedError = simulation.NotKeptGenerationError
+
+		actualCellState, actualError := gameSimulation.StepBack()
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+		assert.Equal(t, 1, gameSimulation.GetGeneration())
+	})
+
+	t.Run("should go back to the states kept and forward to the same states", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		gameSimulation.KeepHistory(3)
+		gameSimulation.Step()
+		secondState := gameSimulation.Step()
+		thirdState := gameSimulation.Step()
+
+		firstState, firstError := gameSimulation.StepBack()
+		secondBackState, secondError := gameSimulation.StepBack()
+		_, thirdError := gameSimulation.StepBack()
+
+		assert.Nil(t, firstError)
+		assert.Same(t, secondState, firstState)
+		assert.Nil(t, secondError)
+		assert.Equal(t, 1, gameSimulation.GetGeneration())
+		assert.EqualError(t, thirdError, simulation.NotKeptGenerationError)
+		assert.NotNil(t, secondBackState)
+		assert.Same(t, secondState, gameSimulation.Step())
+		assert.Same(t, thirdState, gameSimulation.Step())
+	})
+}
+
+func TestJumpTo(t *testing.T) {
+	t.Run("should return nil and error for negative generation", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		var expectedError = simulation.NegativeGenerationError
+
+		actualCellState, actualError := gameSimulation.JumpTo(-1)
+
+		assert.Nil(t, actualCellState)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should step forward notifying every generation", func(t *testing.T) {
+		gameSimulation := newSimulation(blinkerGeneration)
+		notified := make([]int, 0)
+		gameSimulation.OnGeneration(func(generation int, cellState *cell.CellState) {
+			notified = append(notified, generation)
+		})
+
+		actualCellState, actualError := gameSimulation.JumpTo(3)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, 3, gameSimulation.GetGeneration())
+		assert.Equal(t, gameSimulation.GetCellState(), actualCellState)
+		assert.Equal(t, []int{1, 2, 3}, notified)
+	})
+
+	t.Run("should go back and restore whether it is stable or extinct", func(t *testing.T) {
+		stableSimulation := newSimulation(preBlockGeneration)
+		stableSimulation.KeepHistory(5)
+		stableSimulation.StepN(3)
+		extinctSimulation := newSimulation(dominoGeneration)
+		extinctSimulation.KeepHistory(5)
+		extinctSimulation.StepN(2)
+
+		_, stableError := stableSimulation.JumpTo(1)
+		_, extinctError := extinctSimulation.JumpTo(0)
+
+		assert.Nil(t, stableError)
+		assert.False(t, stableSimulation.IsStable())
+		stableSimulation.Step()
+		assert.True(t, stableSimulation.IsStable())
+		assert.Nil(t, extinctError)
+		assert.False(t, extinctSimulation.IsExtinct())
+		assert.EqualValues(t, dominoGeneration, extinctSimulation.GetCellState().GetGeneration())
+	})
+}
@@ -0,0 +1,243 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/irainia/gameoflife-go/cell"
+)
+
+const (
+	CSVExtension       = ".csv"
+	JSONLinesExtension = ".jsonl"
+)
+
+const (
+	PathEmptyError        = "stats path passed is empty"
+	InvalidExtensionError = "invalid stats file extension (file should be *.csv or *.jsonl)"
+	NilCellStateError     = "cell state passed is nil"
+)
+
+var (
+	ErrPathEmpty        = errors.New(PathEmptyError)
+	ErrInvalidExtension = errors.New(InvalidExtensionError)
+	ErrNilCellState     = errors.New(NilCellStateError)
+)
+
+var (
+	csvHeader = []string{
+		"generation", "population", "births", "deaths",
+		"width", "height", "density", "centroid_row", "centroid_col",
+	}
+)
+
+type Statistic struct {
+	Generation  int     `json:"generation"`
+	Population  int     `json:"population"`
+	Births      int     `json:"births"`
+	Deaths      int     `json:"deaths"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	Density     float64 `json:"density"`
+	CentroidRow float64 `json:"centroidRow"`
+	CentroidCol float64 `json:"centroidCol"`
+}
+
+type Recorder struct {
+	path string
+
+	file        *os.File
+	buffer      *bufio.Writer
+	csvWriter   *csv.Writer
+	jsonEncoder *json.Encoder
+
+	previousState *cell.CellState
+	err           error
+}
+
+// Record measures cellState against the previously recorded one and appends
+// it to the stats file. The first error is kept and returned by Close.
+func (recorder *Recorder) Record(generation int, cellState *cell.CellState) {
+	if recorder.err != nil {
+		return
+	}
+	if recorder.file == nil {
+		recorder.err = recorder.open()
+		if recorder.err != nil {
+			return
+		}
+	}
+
+	statistic, err := Measure(generation, recorder.previousState, cellState)
+	if err != nil {
+		recorder.err = err
+		return
+	}
+	recorder.previousState = cellState
+
+	if recorder.csvWriter != nil {
+		recorder.err = recorder.csvWriter.Write(toCSVRecord(statistic))
+	} else {
+		recorder.err = recorder.jsonEncoder.Encode(statistic)
+	}
+}
+
+// Resume opens the stats file of an interrupted run for appending, so the
+// statistics recorded before the checkpoint are kept. cellState is recorded
+// only if the file holds no statistic yet, otherwise it is just measured
+// against by the next record.
+func (recorder *Recorder) Resume(generation int, cellState *cell.CellState) {
+	if recorder.err != nil || recorder.file != nil {
+		return
+	}
+	isEmpty, err := recorder.openAppend()
+	if err != nil {
+		recorder.err = err
+		return
+	}
+	if isEmpty {
+		recorder.Record(generation, cellState)
+		return
+	}
+	recorder.previousState = cellState
+}
+
+func (recorder *Recorder) GetPath() string {
+	return recorder.path
+}
+
+func (recorder *Recorder) Close() error {
+	if recorder.file == nil {
+		return recorder.err
+	}
+	if recorder.csvWriter != nil {
+		recorder.csvWriter.Flush()
+	}
+	flushErr := recorder.buffer.Flush()
+	closeErr := recorder.file.Close()
+	if recorder.err != nil {
+		return recorder.err
+	}
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
+
+func New(path string) (*Recorder, error) {
+	if path == "" {
+		return nil, ErrPathEmpty
+	}
+	extension := filepath.Ext(path)
+	if extension != CSVExtension && extension != JSONLinesExtension {
+		return nil, ErrInvalidExtension
+	}
+
+	var recorder = Recorder{
+		path: path,
+	}
+	return &recorder, nil
+}
+
+func (recorder *Recorder) open() error {
+	file, err := os.Create(recorder.path)
+	if err != nil {
+		return err
+	}
+
+	return recorder.setFile(file, true)
+}
+
+func (recorder *Recorder) openAppend() (bool, error) {
+	file, err := os.OpenFile(recorder.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return false, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return false, err
+	}
+
+	isEmpty := info.Size() == 0
+	return isEmpty, recorder.setFile(file, isEmpty)
+}
+
+func (recorder *Recorder) setFile(file *os.File, withHeader bool) error {
+	recorder.file = file
+	recorder.buffer = bufio.NewWriter(file)
+	if filepath.Ext(recorder.path) == CSVExtension {
+		recorder.csvWriter = csv.NewWriter(recorder.buffer)
+		if withHeader {
+			return recorder.csvWriter.Write(csvHeader)
+		}
+		return nil
+	}
+	recorder.jsonEncoder = json.NewEncoder(recorder.buffer)
+	return nil
+}
+
+// Measure computes the statistic of current. Births and deaths are counted
+// against previous, which may be nil for the first generation of a run.
+func Measure(generation int, previous, current *cell.CellState) (Statistic, error) {
+	if current == nil {
+		return Statistic{}, ErrNilCellState
+	}
+
+	currentGeneration := current.GetGeneration()
+	rowOffset, colOffset := current.GetOffset()
+	statistic := Statistic{
+		Generation: generation,
+	}
+
+	rowSum, colSum := 0, 0
+	for i := 0; i < len(currentGeneration); i++ {
+		for j := 0; j < len(currentGeneration[i]); j++ {
+			if !currentGeneration[i][j] {
+				continue
+			}
+			statistic.Population++
+			rowSum += rowOffset + i
+			colSum += colOffset + j
+			if previous != nil && !previous.IsAlive(rowOffset+i, colOffset+j) {
+				statistic.Births++
+			}
+		}
+	}
+
+	if previous != nil {
+		statistic.Deaths = previous.GetPopulation() - (statistic.Population - statistic.Births)
+	}
+	if statistic.Population > 0 {
+		statistic.Height = len(currentGeneration)
+		statistic.Width = len(currentGeneration[0])
+		statistic.Density = float64(statistic.Population) / float64(statistic.Width*statistic.Height)
+		statistic.CentroidRow = float64(rowSum) / float64(statistic.Population)
+		statistic.CentroidCol = float64(colSum) / float64(statistic.Population)
+	}
+
+	return statistic, nil
+}
+
+func toCSVRecord(statistic Statistic) []string {
+	return []string{
+		strconv.Itoa(statistic.Generation),
+		strconv.Itoa(statistic.Population),
+		strconv.Itoa(statistic.Births),
+		strconv.Itoa(statistic.Deaths),
+		strconv.Itoa(statistic.Width),
+		strconv.Itoa(statistic.Height),
+		formatFloat(statistic.Density),
+		formatFloat(statistic.CentroidRow),
+		formatFloat(statistic.CentroidCol),
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,234 @@
+package stats_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	blinkerGeneration = [][]bool{
+		{true, true, true},
+	}
+	gliderGeneration = [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+)
+
+func makeDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return directory
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should return nil and error for empty path", func(t *testing.T) {
+		var expectedError = stats.PathEmptyError
+
+		actualRecorder, actualError := stats.New("")
+
+		assert.Nil(t, actualRecorder)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return nil and error for invalid extension", func(t *testing.T) {
+		var expectedError = stats.InvalidExtensionError
+
+		actualRecorder, actualError := stats.New("stats.txt")
+
+		assert.Nil(t, actualRecorder)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should not create file before recording", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "stats.csv")
+
+		actualRecorder, actualError := stats.New(path)
+		_, statError := os.Stat(path)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, path, actualRecorder.GetPath())
+		assert.True(t, os.IsNotExist(statError))
+	})
+}
+
+func TestMeasure(t *testing.T) {
+	t.Run("should return error for nil cell state", func(t *testing.T) {
+		var expectedError = stats.NilCellStateError
+
+		_, actualError := stats.Measure(0, nil, nil)
+
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should measure first generation without births and deaths", func(t *testing.T) {
+		cellState, _ := cell.New(gliderGeneration)
+		var expectedStatistic = stats.Statistic{
+			Generation:  0,
+			Population:  5,
+			Width:       3,
+			Height:      3,
+			Density:     5.0 / 9.0,
+			CentroidRow: 7.0 / 5.0,
+			CentroidCol: 6.0 / 5.0,
+		}
+
+		actualStatistic, actualError := stats.Measure(0, nil, cellState)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedStatistic, actualStatistic)
+	})
+
+	t.Run("should count births and deaths against previous generation", func(t *testing.T) {
+		previousState, _ := cell.New(blinkerGeneration)
+		cellState := previousState.GetNextState()
+		var expectedStatistic = stats.Statistic{
+			Generation:  1,
+			Population:  3,
+			Births:      2,
+			Deaths:      2,
+			Width:       1,
+			Height:      3,
+			Density:     1,
+			CentroidRow: 0,
+			CentroidCol: 1,
+		}
+
+		actualStatistic, actualError := stats.Measure(1, previousState, cellState)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedStatistic, actualStatistic)
+	})
+
+	t.Run("should measure extinct generation", func(t *testing.T) {
+		previousState, _ := cell.New([][]bool{{true, true}})
+		cellState := previousState.GetNextState()
+		var expectedStatistic = stats.Statistic{
+			Generation: 1,
+			Deaths:     2,
+		}
+
+		actualStatistic, actualError := stats.Measure(1, previousState, cellState)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedStatistic, actualStatistic)
+	})
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("should write header and one csv row per generation", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "stats.csv")
+		recorder, _ := stats.New(path)
+		cellState, _ := cell.New(blinkerGeneration)
+		var expectedContent = strings.Join([]string{
+			"generation,population,births,deaths,width,height,density,centroid_row,centroid_col",
+			"0,3,0,0,3,1,1,0,1",
+			"1,3,2,2,1,3,1,0,1",
+			"",
+		}, "\n")
+
+		recorder.Record(0, cellState)
+		recorder.Record(1, cellState.GetNextState())
+		actualError := recorder.Close()
+		actualContent, _ := ioutil.ReadFile(path)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedContent, string(actualContent))
+	})
+
+	t.Run("should write one json line per generation", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "stats.jsonl")
+		recorder, _ := stats.New(path)
+		cellState, _ := cell.New(blinkerGeneration)
+		var expectedContent = strings.Join([]string{
+			`{"generation":0,"population":3,"births":0,"deaths":0,"width":3,"height":1,"density":1,"centroidRow":0,"centroidCol":1}`,
+			`{"generation":1,"population":3,"births":2,"deaths":2,"width":1,"height":3,"density":1,"centroidRow":0,"centroidCol":1}`,
+			"",
+		}, "\n")
+
+		recorder.Record(0, cellState)
+		recorder.Record(1, cellState.GetNextState())
+		actualError := recorder.Close()
+		actualContent, _ := ioutil.ReadFile(path)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedContent, string(actualContent))
+	})
+
+	t.Run("should return error on close for unwritable path", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "missing", "stats.csv")
+		recorder, _ := stats.New(path)
+		cellState, _ := cell.New(blinkerGeneration)
+
+		recorder.Record(0, cellState)
+		actualError := recorder.Close()
+
+		assert.NotNil(t, actualError)
+	})
+}
+
+func TestResume(t *testing.T) {
+	t.Run("should append to statistics recorded before the checkpoint", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "stats.csv")
+		cellState, _ := cell.New(blinkerGeneration)
+		firstRecorder, _ := stats.New(path)
+		firstRecorder.Record(0, cellState)
+		firstRecorder.Close()
+		recorder, _ := stats.New(path)
+		var expectedContent = strings.Join([]string{
+			"generation,population,births,deaths,width,height,density,centroid_row,centroid_col",
+			"0,3,0,0,3,1,1,0,1",
+			"1,3,2,2,1,3,1,0,1",
+			"",
+		}, "\n")
+
+		recorder.Resume(0, cellState)
+		recorder.Record(1, cellState.GetNextState())
+		actualError := recorder.Close()
+		actualContent, _ := ioutil.ReadFile(path)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedContent, string(actualContent))
+	})
+
+	t.Run("should record resumed generation for missing file", func(t *testing.T) {
+		directory := makeDirectory(t)
+		defer os.RemoveAll(directory)
+		path := filepath.Join(directory, "stats.jsonl")
+		recorder, _ := stats.New(path)
+		cellState, _ := cell.New(blinkerGeneration)
+		var expectedContent = strings.Join([]string{
+			`{"generation":4,"population":3,"births":0,"deaths":0,"width":3,"height":1,"density":1,"centroidRow":0,"centroidCol":1}`,
+			`{"generation":5,"population":3,"births":2,"deaths":2,"width":1,"height":3,"density":1,"centroidRow":0,"centroidCol":1}`,
+			"",
+		}, "\n")
+
+		recorder.Resume(4, cellState)
+		recorder.Record(5, cellState.GetNextState())
+		actualError := recorder.Close()
+		actualContent, _ := ioutil.ReadFile(path)
+
+		assert.Nil(t, actualError)
+		assert.Equal(t, expectedContent, string(actualContent))
+	})
+}
@@ -0,0 +1,196 @@
+package transform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+
+	"github.com/irainia/gameoflife-go/cell"
+)
+
+const (
+	UnknownTransformError = "unknown transform (use: identity/rot90/rot180/rot270/flip-horizontal/flip-vertical/flip-diagonal/flip-antidiagonal)"
+)
+
+var (
+	ErrUnknownTransform = errors.New(UnknownTransformError)
+)
+
+const (
+	Identity         = "identity"
+	Rotate90         = "rot90"
+	Rotate180        = "rot180"
+	Rotate270        = "rot270"
+	FlipHorizontal   = "flip-horizontal"
+	FlipVertical     = "flip-vertical"
+	FlipDiagonal     = "flip-diagonal"
+	FlipAntiDiagonal = "flip-antidiagonal"
+)
+
+// Transform moves every cell of a grid to its place after a rotation or
+// reflection, leaving its argument untouched. The grid is expected to be a
+// rectangle.
+type Transform struct {
+	isTransposed  bool
+	isRowReversed bool
+	isColReversed bool
+}
+
+var (
+	identity         = &Transform{}
+	rotate90         = &Transform{isTransposed: true, isColReversed: true}
+	rotate180        = &Transform{isRowReversed: true, isColReversed: true}
+	rotate270        = &Transform{isTransposed: true, isRowReversed: true}
+	flipHorizontal   = &Transform{isColReversed: true}
+	flipVertical     = &Transform{isRowReversed: true}
+	flipDiagonal     = &Transform{isTransposed: true}
+	flipAntiDiagonal = &Transform{isTransposed: true, isRowReversed: true, isColReversed: true}
+)
+
+var transforms = map[string]*Transform{
+	Identity:         identity,
+	Rotate90:         rotate90,
+	Rotate180:        rotate180,
+	Rotate270:        rotate270,
+	FlipHorizontal:   flipHorizontal,
+	FlipVertical:     flipVertical,
+	FlipDiagonal:     flipDiagonal,
+	FlipAntiDiagonal: flipAntiDiagonal,
+}
+
+func Get(name string) (*Transform, error) {
+	transform, isFound := transforms[name]
+	if !isFound {
+		return nil, ErrUnknownTransform
+	}
+
+	return transform, nil
+}
+
+func GetNames() []string {
+	names := make([]string, 0, len(transforms))
+	for name := range transforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (transform *Transform) Apply(generation [][]bool) [][]bool {
+	height, width := 0, 0
+	if len(generation) > 0 {
+		height, width = transform.getSize(len(generation), len(generation[0]))
+	}
+
+	transformed := make([][]bool, height)
+	for i := 0; i < height; i++ {
+		transformed[i] = make([]bool, width)
+		for j := 0; j < width; j++ {
+			row, col := transform.getSource(i, j, height, width)
+			transformed[i][j] = generation[row][col]
+		}
+	}
+
+	return transformed
+}
+
+// ApplyToStates is Apply for a grid of cell states.
+func (transform *Transform) ApplyToStates(states [][]uint8) [][]uint8 {
+	height, width := 0, 0
+	if len(states) > 0 {
+		height, width = transform.getSize(len(states), len(states[0]))
+	}
+
+	transformed := make([][]uint8, height)
+	for i := 0; i < height; i++ {
+		transformed[i] = make([]uint8, width)
+		for j := 0; j < width; j++ {
+			row, col := transform.getSource(i, j, height, width)
+			transformed[i][j] = states[row][col]
+		}
+	}
+
+	return transformed
+}
+
+// getSize returns the height and width of a grid of the given height and
+// width once transformed.
+func (transform *Transform) getSize(height, width int) (int, int) {
+	if transform.isTransposed {
+		return width, height
+	}
+
+	return height, width
+}
+
+// getSource returns where cell (i, j) of the transformed grid of the given
+// height and width is taken from.
+func (transform *Transform) getSource(i, j, height, width int) (int, int) {
+	if transform.isRowReversed {
+		i = height - 1 - i
+	}
+	if transform.isColReversed {
+		j = width - 1 - j
+	}
+	if transform.isTransposed {
+		return j, i
+	}
+
+	return i, j
+}
+
+// Orientations returns generation under each of the eight rotations and
+// reflections of the square, starting with generation itself.
+func Orientations(generation [][]bool) [][][]bool {
+	return [][][]bool{
+		identity.Apply(generation),
+		rotate90.Apply(generation),
+		rotate180.Apply(generation),
+		rotate270.Apply(generation),
+		flipHorizontal.Apply(generation),
+		flipVertical.Apply(generation),
+		flipDiagonal.Apply(generation),
+		flipAntiDiagonal.Apply(generation),
+	}
+}
+
+// Canonical trims generation and returns the orientation with the smallest
+// cell string, so every orientation and translation of a pattern share it.
+func Canonical(generation [][]bool) ([][]bool, error) {
+	cellState, err := cell.New(generation)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := cellState.GetGeneration()
+	if len(trimmed) == 0 {
+		return trimmed, nil
+	}
+	canonical, canonicalString := trimmed, cellState.String()
+	for _, orientation := range Orientations(trimmed)[1:] {
+		orientationState, _ := cell.New(orientation)
+		if orientationString := orientationState.String(); orientationString < canonicalString {
+			canonical, canonicalString = orientation, orientationString
+		}
+	}
+
+	return canonical, nil
+}
+
+// Hash returns a hex-encoded SHA-256 of the canonical generation.
+func Hash(generation [][]bool) (string, error) {
+	canonical, err := Canonical(generation)
+	if err != nil {
+		return "", err
+	}
+
+	var canonicalString string
+	if len(canonical) > 0 {
+		cellState, _ := cell.New(canonical)
+		canonicalString = cellState.String()
+	}
+	sum := sha256.Sum256([]byte(canonicalString))
+	return hex.EncodeToString(sum[:]), nil
+}
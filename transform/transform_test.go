@@ -0,0 +1,194 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/irainia/gameoflife-go/cell"
+	"github.com/irainia/gameoflife-go/transform"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	lGeneration = [][]bool{
+		{true, false},
+		{true, false},
+		{true, true},
+	}
+	gliderGeneration = [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{true, true, true},
+	}
+)
+
+func TestGet(t *testing.T) {
+	t.Run("should return nil and error for unknown transform", func(t *testing.T) {
+		var expectedError = transform.UnknownTransformError
+
+		actualTransform, actualError := transform.Get("rot45")
+
+		assert.Nil(t, actualTransform)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return transform of each name", func(t *testing.T) {
+		testCases := []struct {
+			name               string
+			expectedGeneration [][]bool
+		}{
+			{transform.Identity, lGeneration},
+			{transform.Rotate90, [][]bool{
+				{true, true, true},
+				{true, false, false},
+			}},
+			{transform.Rotate180, [][]bool{
+				{true, true},
+				{false, true},
+				{false, true},
+			}},
+			{transform.Rotate270, [][]bool{
+				{false, false, true},
+				{true, true, true},
+			}},
+			{transform.FlipHorizontal, [][]bool{
+				{false, true},
+				{false, true},
+				{true, true},
+			}},
+			{transform.FlipVertical, [][]bool{
+				{true, true},
+				{true, false},
+				{true, false},
+			}},
+			{transform.FlipDiagonal, [][]bool{
+				{true, true, true},
+				{false, false, true},
+			}},
+			{transform.FlipAntiDiagonal, [][]bool{
+				{true, false, false},
+				{true, true, true},
+			}},
+		}
+
+		for _, testCase := range testCases {
+			actualTransform, actualError := transform.Get(testCase.name)
+
+			assert.Nil(t, actualError)
+			assert.EqualValues(t, testCase.expectedGeneration, actualTransform.Apply(lGeneration), testCase.name)
+		}
+	})
+
+	t.Run("should not change the generation passed", func(t *testing.T) {
+		generation := [][]bool{{true, false}}
+		identity, _ := transform.Get(transform.Identity)
+
+		transformed := identity.Apply(generation)
+		transformed[0][1] = true
+
+		assert.False(t, generation[0][1])
+	})
+}
+
+func TestGetNames(t *testing.T) {
+	t.Run("should return every transform name", func(t *testing.T) {
+		actualNames := transform.GetNames()
+
+		assert.Len(t, actualNames, 8)
+		assert.Contains(t, actualNames, transform.Rotate90)
+	})
+}
+
+func TestOrientations(t *testing.T) {
+	t.Run("should return eight orientations starting with the generation", func(t *testing.T) {
+		actualOrientations := transform.Orientations(lGeneration)
+
+		assert.Len(t, actualOrientations, 8)
+		assert.EqualValues(t, lGeneration, actualOrientations[0])
+	})
+}
+
+func TestCanonical(t *testing.T) {
+	t.Run("should return nil and error for invalid generation", func(t *testing.T) {
+		var expectedError = cell.GenerationNilError
+
+		actualGeneration, actualError := transform.Canonical(nil)
+
+		assert.Nil(t, actualGeneration)
+		assert.EqualError(t, actualError, expectedError)
+	})
+
+	t.Run("should return same generation for every orientation and translation", func(t *testing.T) {
+		expectedGeneration, _ := transform.Canonical(gliderGeneration)
+		padded := [][]bool{
+			{false, false, false, false},
+			{false, true, true, true},
+			{false, true, false, false},
+			{false, false, true, false},
+		}
+
+		for _, orientation := range transform.Orientations(gliderGeneration) {
+			actualGeneration, actualError := transform.Canonical(orientation)
+
+			assert.EqualValues(t, expectedGeneration, actualGeneration)
+			assert.Nil(t, actualError)
+		}
+		actualGeneration, _ := transform.Canonical(padded)
+		assert.EqualValues(t, expectedGeneration, actualGeneration)
+	})
+}
+
+func TestHash(t *testing.T) {
+	t.Run("should return same hash for every orientation", func(t *testing.T) {
+		expectedHash, _ := transform.Hash(lGeneration)
+
+		for _, orientation := range transform.Orientations(lGeneration) {
+			actualHash, actualError := transform.Hash(orientation)
+
+			assert.Equal(t, expectedHash, actualHash)
+			assert.Nil(t, actualError)
+		}
+	})
+
+	t.Run("should return different hash for different patterns", func(t *testing.T) {
+		lHash, _ := transform.Hash(lGeneration)
+		gliderHash, _ := transform.Hash(gliderGeneration)
+
+		assert.NotEqual(t, lHash, gliderHash)
+		assert.Len(t, lHash, 64)
+	})
+}
+
+func TestApplyToStates(t *testing.T) {
+	t.Run("should move every state with the transform", func(t *testing.T) {
+		states := [][]uint8{
+			{1, 2},
+			{0, 3},
+		}
+		expectedStates := [][]uint8{
+			{0, 1},
+			{3, 2},
+		}
+		rotate90, _ := transform.Get(transform.Rotate90)
+
+		actualStates := rotate90.ApplyToStates(states)
+
+		assert.EqualValues(t, expectedStates, actualStates)
+	})
+
+	t.Run("should move states like living cells for every transform", func(t *testing.T) {
+		states := [][]uint8{
+			{1, 0},
+			{1, 0},
+			{1, 1},
+		}
+
+		for _, name := range transform.GetNames() {
+			actualTransform, _ := transform.Get(name)
+			expectedCellState, _ := cell.New(actualTransform.Apply(lGeneration))
+
+			actualStates := actualTransform.ApplyToStates(states)
+
+			assert.EqualValues(t, expectedCellState.GetStates(), actualStates, name)
+		}
+	})
+}